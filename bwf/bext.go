@@ -0,0 +1,144 @@
+package bwf
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+)
+
+// bextLen is the length of the fixed part of a bext chunk before the coding history.
+const bextLen = 602
+
+// Bext is the broadcast extension chunk of a Broadcast WAV file as defined by EBU Tech 3285. The strings
+// are ASCII and are limited to the length of their field in the chunk.
+type Bext struct {
+	// Description is a free text description of the sound of up to 256 characters.
+	Description string
+
+	// Originator is the name of the originator of up to 32 characters such as the recorder model.
+	Originator string
+
+	// OriginatorReference is a unique reference of up to 32 characters given by the originator.
+	OriginatorReference string
+
+	// OriginationDate is the date the file was created in the form yyyy-mm-dd.
+	OriginationDate string
+
+	// OriginationTime is the time the file was created in the form hh:mm:ss.
+	OriginationTime string
+
+	// TimeReference is the number of samples since midnight of the first sample of the file at the
+	// sample rate of the file.
+	TimeReference uint64
+
+	// Version is the version of the bext chunk. The loudness fields are only defined for version 2.
+	Version uint16
+
+	// UMID is the SMPTE UMID of the file.
+	UMID [64]byte
+
+	// LoudnessValue is the integrated loudness in LUFS multiplied by 100.
+	LoudnessValue int16
+
+	// LoudnessRange is the loudness range in LU multiplied by 100.
+	LoudnessRange int16
+
+	// MaxTruePeakLevel is the maximum true peak level in dBTP multiplied by 100.
+	MaxTruePeakLevel int16
+
+	// MaxMomentaryLoudness is the highest momentary loudness in LUFS multiplied by 100.
+	MaxMomentaryLoudness int16
+
+	// MaxShortTermLoudness is the highest short term loudness in LUFS multiplied by 100.
+	MaxShortTermLoudness int16
+
+	// CodingHistory is the coding history of the file made of lines ending in CR LF.
+	CodingHistory string
+}
+
+// bextField is a fixed length string field of a bext chunk.
+type bextField struct {
+	value  *string
+	name   string
+	offset int
+	length int
+}
+
+// fields returns the fixed length string fields of the bext chunk.
+func (b *Bext) fields() []bextField {
+	return []bextField{
+		{&b.Description, "description", 0, 256},
+		{&b.Originator, "originator", 256, 32},
+		{&b.OriginatorReference, "originator reference", 288, 32},
+		{&b.OriginationDate, "origination date", 320, 10},
+		{&b.OriginationTime, "origination time", 330, 8},
+	}
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler and returns the data of the bext chunk. An error is
+// returned if a string is longer than its field.
+func (b *Bext) MarshalBinary() ([]byte, error) {
+	data := make([]byte, bextLen, bextLen+len(b.CodingHistory))
+
+	for _, field := range b.fields() {
+		if len(*field.value) > field.length {
+			return nil, fmt.Errorf("bext %s must be at most %d characters got: %d", field.name, field.length, len(*field.value))
+		}
+		copy(data[field.offset:], *field.value)
+	}
+
+	binary.LittleEndian.PutUint32(data[338:], uint32(b.TimeReference))
+	binary.LittleEndian.PutUint32(data[342:], uint32(b.TimeReference>>32))
+	binary.LittleEndian.PutUint16(data[346:], b.Version)
+	copy(data[348:], b.UMID[:])
+
+	for i, loudness := range []int16{
+		b.LoudnessValue,
+		b.LoudnessRange,
+		b.MaxTruePeakLevel,
+		b.MaxMomentaryLoudness,
+		b.MaxShortTermLoudness,
+	} {
+		binary.LittleEndian.PutUint16(data[412+i*2:], uint16(loudness))
+	}
+
+	return append(data, b.CodingHistory...), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler and reads the data of a bext chunk. Strings end
+// at their first NUL character. An error is returned if data is shorter than the fixed part of the chunk.
+func (b *Bext) UnmarshalBinary(data []byte) error {
+	if len(data) < bextLen {
+		return fmt.Errorf("bext chunk must be at least %d bytes got: %d", bextLen, len(data))
+	}
+
+	for _, field := range b.fields() {
+		*field.value = cString(data[field.offset : field.offset+field.length])
+	}
+
+	b.TimeReference = uint64(binary.LittleEndian.Uint32(data[338:])) |
+		uint64(binary.LittleEndian.Uint32(data[342:]))<<32
+	b.Version = binary.LittleEndian.Uint16(data[346:])
+	copy(b.UMID[:], data[348:412])
+
+	for i, loudness := range []*int16{
+		&b.LoudnessValue,
+		&b.LoudnessRange,
+		&b.MaxTruePeakLevel,
+		&b.MaxMomentaryLoudness,
+		&b.MaxShortTermLoudness,
+	} {
+		*loudness = int16(binary.LittleEndian.Uint16(data[412+i*2:]))
+	}
+
+	b.CodingHistory = cString(data[bextLen:])
+	return nil
+}
+
+// cString returns data up to its first NUL character as a string.
+func cString(data []byte) string {
+	if i := bytes.IndexByte(data, 0); i >= 0 {
+		data = data[:i]
+	}
+	return string(data)
+}
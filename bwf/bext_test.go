@@ -0,0 +1,48 @@
+package bwf
+
+import (
+	"encoding/binary"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBext(t *testing.T) {
+	t.Parallel()
+
+	bext := Bext{
+		Description:         "sc 12 tk 3",
+		Originator:          "Sound Devices 833",
+		OriginatorReference: "USSDVA0123456789",
+		OriginationDate:     "2024-05-17",
+		OriginationTime:     "14:32:05",
+		TimeReference:       0x123456789a,
+		Version:             2,
+		LoudnessValue:       -2300,
+		MaxTruePeakLevel:    -100,
+		CodingHistory:       "A=PCM,F=48000,W=24,M=mono,T=833\r\n",
+	}
+	bext.UMID[0] = 0x06
+
+	data, err := bext.MarshalBinary()
+	assert.Nil(t, err)
+	assert.Equal(t, bextLen+len(bext.CodingHistory), len(data))
+	assert.Equal(t, uint32(0x3456789a), binary.LittleEndian.Uint32(data[338:]))
+	assert.Equal(t, uint32(0x12), binary.LittleEndian.Uint32(data[342:]))
+
+	var back Bext
+	assert.Nil(t, back.UnmarshalBinary(data))
+	assert.Equal(t, bext, back)
+
+	// the coding history may be padded with NUL characters
+	back = Bext{}
+	assert.Nil(t, back.UnmarshalBinary(append(data, 0, 0)))
+	assert.Equal(t, bext, back)
+
+	assert.NotNil(t, back.UnmarshalBinary(data[:bextLen-1]))
+
+	bext.Originator = strings.Repeat("x", 33)
+	_, err = bext.MarshalBinary()
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,366 @@
+// Package bwf reads and writes the timecode of Broadcast WAV files. The start of a recording is stored as
+// the number of samples since midnight in the TimeReference of the bext chunk and the timecode rate is
+// stored in the TIMECODE_RATE and TIMECODE_FLAG elements of the iXML chunk. Only RIFF/WAVE files are
+// supported, RF64 files larger than 4 GiB are not.
+package bwf
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	timecode "github.com/agorman/go-timecode/v2"
+)
+
+const (
+	chunkFmt  = "fmt "
+	chunkBext = "bext"
+	chunkIXML = "iXML"
+	chunkData = "data"
+)
+
+var (
+	// ErrNotWave is returned when reading a file that isn't a RIFF/WAVE file.
+	ErrNotWave = errors.New("not a RIFF/WAVE file")
+
+	// ErrNoTimeReference is returned when a file has neither a bext chunk nor an iXML time stamp.
+	ErrNoTimeReference = errors.New("file has no time reference")
+
+	// ErrNoRate is returned when a file has no iXML TIMECODE_RATE.
+	ErrNoRate = errors.New("file has no timecode rate")
+)
+
+// File is a RIFF/WAVE file. The fmt, bext and iXML chunks are read into memory when the File is read and
+// the other chunks such as the audio data are copied from the source when the File is written. The
+// source must stay open until the File is written.
+type File struct {
+	// SampleRate is the number of samples per second of the audio from the fmt chunk.
+	SampleRate int64
+
+	// Bext is the bext chunk or nil if the file doesn't have one. Setting it to nil removes the chunk when
+	// the File is written.
+	Bext *Bext
+
+	// IXML is the iXML chunk or nil if the file doesn't have one. Setting it to nil removes the chunk when
+	// the File is written.
+	IXML *IXML
+
+	src    io.ReadSeeker
+	chunks []chunk
+}
+
+// chunk is a chunk of a RIFF file. The data of the fmt chunk and chunks that aren't read into memory are
+// copied from offset in the source when the File is written.
+type chunk struct {
+	id     string
+	offset int64
+	size   int64
+	data   []byte
+}
+
+// Read reads the chunks of the RIFF/WAVE file r. An ErrNotWave error is returned if r isn't a RIFF/WAVE
+// file and an error is returned if it doesn't have a valid fmt chunk or its bext or iXML chunks can't be
+// read.
+func Read(r io.ReadSeeker) (*File, error) {
+	var header [12]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNotWave, err)
+	}
+	if string(header[0:4]) != "RIFF" || string(header[8:12]) != "WAVE" {
+		return nil, ErrNotWave
+	}
+
+	f := &File{
+		src: r,
+	}
+
+	// the RIFF size of a file that wasn't closed properly can be larger than the file
+	end := 8 + int64(binary.LittleEndian.Uint32(header[4:]))
+	size, err := r.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, err
+	}
+	if size < end {
+		end = size
+	}
+
+	for offset := int64(len(header)); offset+8 <= end; {
+		c, err := readChunk(r, offset, end)
+		if err != nil {
+			return nil, err
+		}
+
+		f.chunks = append(f.chunks, c)
+		offset = c.offset + c.size + c.size%2
+	}
+
+	for _, c := range f.chunks {
+		var err error
+		switch c.id {
+		case chunkFmt:
+			if len(c.data) < 16 {
+				return nil, fmt.Errorf("fmt chunk must be at least 16 bytes got: %d", len(c.data))
+			}
+			f.SampleRate = int64(binary.LittleEndian.Uint32(c.data[4:]))
+		case chunkBext:
+			f.Bext = &Bext{}
+			err = f.Bext.UnmarshalBinary(c.data)
+		case chunkIXML:
+			f.IXML, err = ParseIXML(c.data)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if f.SampleRate < 1 {
+		return nil, fmt.Errorf("%w: missing fmt chunk", ErrNotWave)
+	}
+
+	return f, nil
+}
+
+// readChunk reads the header of the chunk at offset in r and the data of the chunks held in memory. The
+// data of a chunk held in memory must end before end.
+func readChunk(r io.ReadSeeker, offset, end int64) (chunk, error) {
+	var header [8]byte
+
+	if _, err := r.Seek(offset, io.SeekStart); err != nil {
+		return chunk{}, err
+	}
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return chunk{}, err
+	}
+
+	c := chunk{
+		id:     string(header[:4]),
+		offset: offset + 8,
+		size:   int64(binary.LittleEndian.Uint32(header[4:])),
+	}
+
+	switch c.id {
+	case chunkFmt, chunkBext, chunkIXML:
+		// check the size before allocating so a corrupt size can't allocate up to 4 GiB
+		if c.size > end-c.offset {
+			return c, fmt.Errorf("unable to read %s chunk of %d bytes: %w", c.id, c.size, io.ErrUnexpectedEOF)
+		}
+
+		c.data = make([]byte, c.size)
+		if _, err := io.ReadFull(r, c.data); err != nil {
+			return c, fmt.Errorf("unable to read %s chunk: %w", c.id, err)
+		}
+	}
+
+	return c, nil
+}
+
+// Rate returns the Rate of the timecode from the iXML chunk. An ErrNoRate error is returned if the file
+// doesn't have an iXML TIMECODE_RATE.
+func (f *File) Rate() (timecode.Rate, error) {
+	if f.IXML == nil {
+		return timecode.Rate{}, ErrNoRate
+	}
+	return f.IXML.Rate()
+}
+
+// Timecode returns the timecode of the first sample of the file using the Rate from the iXML chunk. An
+// ErrNoRate error is returned if the file doesn't have an iXML TIMECODE_RATE.
+func (f *File) Timecode() (timecode.Timecode, error) {
+	rate, err := f.Rate()
+	if err != nil {
+		return timecode.Timecode{}, err
+	}
+	return f.TimecodeWithRate(rate)
+}
+
+// TimecodeWithRate returns the timecode of the first sample of the file using rate. This is useful for
+// files without an iXML chunk where the rate is known from elsewhere. The TimeReference of the bext chunk
+// is used if the file has one otherwise the time stamp of the iXML chunk is used. The sample is rounded
+// down to the frame, or sub-frame when rate uses sub-frames, that contains it. An ErrNoTimeReference error
+// is returned if the file has neither.
+func (f *File) TimecodeWithRate(rate timecode.Rate) (timecode.Timecode, error) {
+	var samples uint64
+	sampleRate := f.SampleRate
+
+	switch {
+	case f.Bext != nil:
+		samples = f.Bext.TimeReference
+	case f.IXML != nil:
+		var ixmlRate int64
+		var err error
+		samples, ixmlRate, err = f.IXML.TimeReference()
+		if err != nil {
+			return timecode.Timecode{}, err
+		}
+		if ixmlRate > 0 {
+			sampleRate = ixmlRate
+		}
+	default:
+		return timecode.Timecode{}, ErrNoTimeReference
+	}
+
+	if samples > math.MaxInt64 {
+		return timecode.Timecode{}, fmt.Errorf("%w: time reference of %d samples", timecode.ErrOverflow, samples)
+	}
+
+	return timecode.FromSamples(rate, sampleRate, int64(samples))
+}
+
+// SetTimecode sets the TimeReference of the bext chunk to the first sample of tc and the rate and time
+// stamp of the iXML chunk to match. The bext and iXML chunks are added if the file doesn't have them. An
+// error is returned if tc is negative.
+func (f *File) SetTimecode(tc timecode.Timecode) error {
+	samples, err := tc.Samples(f.SampleRate)
+	if err != nil {
+		return err
+	}
+	if samples < 0 {
+		return fmt.Errorf("time reference can't be negative got: %s", tc)
+	}
+
+	if f.Bext == nil {
+		f.Bext = &Bext{Version: 1}
+	}
+	f.Bext.TimeReference = uint64(samples)
+
+	if f.IXML == nil {
+		f.IXML = NewIXML()
+	}
+	f.IXML.SetRate(tc.Rate())
+	f.IXML.SetTimeReference(uint64(samples), f.SampleRate)
+
+	return nil
+}
+
+// WriteTo implements io.WriterTo and writes the file to w with the current bext and iXML chunks. A new
+// bext chunk is written before the fmt chunk and a new iXML chunk is written before the data chunk. The
+// other chunks are copied from the source in their original order so w must not write to the source. An
+// error is returned if the file would be larger than 4 GiB.
+func (f *File) WriteTo(w io.Writer) (int64, error) {
+	chunks, err := f.writeChunks()
+	if err != nil {
+		return 0, err
+	}
+
+	size := int64(4)
+	for _, c := range chunks {
+		size += 8 + c.size + c.size%2
+	}
+	if size > math.MaxUint32 {
+		return 0, fmt.Errorf("file of %d bytes is too large for RIFF", size+8)
+	}
+
+	cw := &countWriter{w: w}
+
+	var header [12]byte
+	copy(header[0:], "RIFF")
+	binary.LittleEndian.PutUint32(header[4:], uint32(size))
+	copy(header[8:], "WAVE")
+	if _, err := cw.Write(header[:]); err != nil {
+		return cw.n, err
+	}
+
+	for _, c := range chunks {
+		if err := f.writeChunk(cw, c); err != nil {
+			return cw.n, err
+		}
+	}
+
+	return cw.n, nil
+}
+
+// writeChunks returns the chunks to write with the data of the bext and iXML chunks set.
+func (f *File) writeChunks() ([]chunk, error) {
+	var bext, ixml []byte
+	if f.Bext != nil {
+		var err error
+		bext, err = f.Bext.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if f.IXML != nil {
+		ixml = f.IXML.Bytes()
+	}
+
+	chunks := make([]chunk, 0, len(f.chunks)+2)
+	for _, c := range f.chunks {
+		switch c.id {
+		case chunkBext:
+			if bext != nil {
+				chunks = append(chunks, chunk{id: c.id, size: int64(len(bext)), data: bext})
+				bext = nil
+			}
+			continue
+		case chunkIXML:
+			if ixml != nil {
+				chunks = append(chunks, chunk{id: c.id, size: int64(len(ixml)), data: ixml})
+				ixml = nil
+			}
+			continue
+		case chunkFmt:
+			if bext != nil {
+				chunks = append(chunks, chunk{id: chunkBext, size: int64(len(bext)), data: bext})
+				bext = nil
+			}
+		case chunkData:
+			if ixml != nil {
+				chunks = append(chunks, chunk{id: chunkIXML, size: int64(len(ixml)), data: ixml})
+				ixml = nil
+			}
+		}
+		chunks = append(chunks, c)
+	}
+
+	if ixml != nil {
+		chunks = append(chunks, chunk{id: chunkIXML, size: int64(len(ixml)), data: ixml})
+	}
+
+	return chunks, nil
+}
+
+// writeChunk writes the header, data and pad byte of c to w. The data is copied from the source if it
+// isn't held in memory.
+func (f *File) writeChunk(w io.Writer, c chunk) error {
+	var header [8]byte
+	copy(header[:], c.id)
+	binary.LittleEndian.PutUint32(header[4:], uint32(c.size))
+	if _, err := w.Write(header[:]); err != nil {
+		return err
+	}
+
+	if c.data != nil {
+		if _, err := w.Write(c.data); err != nil {
+			return err
+		}
+	} else {
+		if _, err := f.src.Seek(c.offset, io.SeekStart); err != nil {
+			return err
+		}
+		if _, err := io.CopyN(w, f.src, c.size); err != nil {
+			return fmt.Errorf("unable to copy %s chunk: %w", c.id, err)
+		}
+	}
+
+	if c.size%2 == 1 {
+		if _, err := w.Write([]byte{0}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// countWriter counts the bytes written to w.
+type countWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
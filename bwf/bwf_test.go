@@ -0,0 +1,211 @@
+package bwf
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"testing"
+
+	timecode "github.com/agorman/go-timecode/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+// testAudio is the data chunk of the test files. It's an odd length so the chunk is padded.
+var testAudio = []byte{1, 2, 3, 4, 5}
+
+// testWave returns a 48 kHz RIFF/WAVE file with the passed chunks between the fmt and data chunks.
+func testWave(chunks ...chunk) []byte {
+	format := make([]byte, 16)
+	binary.LittleEndian.PutUint16(format[0:], 1)
+	binary.LittleEndian.PutUint16(format[2:], 1)
+	binary.LittleEndian.PutUint32(format[4:], 48000)
+	binary.LittleEndian.PutUint32(format[8:], 96000)
+	binary.LittleEndian.PutUint16(format[12:], 2)
+	binary.LittleEndian.PutUint16(format[14:], 16)
+
+	chunks = append([]chunk{{id: chunkFmt, data: format}}, chunks...)
+	chunks = append(chunks, chunk{id: chunkData, data: testAudio})
+
+	var body bytes.Buffer
+	body.WriteString("WAVE")
+	for _, c := range chunks {
+		var header [8]byte
+		copy(header[:], c.id)
+		binary.LittleEndian.PutUint32(header[4:], uint32(len(c.data)))
+		body.Write(header[:])
+		body.Write(c.data)
+		if len(c.data)%2 == 1 {
+			body.WriteByte(0)
+		}
+	}
+
+	var header [8]byte
+	copy(header[:], "RIFF")
+	binary.LittleEndian.PutUint32(header[4:], uint32(body.Len()))
+	return append(header[:], body.Bytes()...)
+}
+
+// chunkIDs returns the ids of the chunks of f in order.
+func chunkIDs(f *File) []string {
+	var ids []string
+	for _, c := range f.chunks {
+		ids = append(ids, c.id)
+	}
+	return ids
+}
+
+func TestRead(t *testing.T) {
+	t.Parallel()
+
+	bext := Bext{Originator: "recorder", TimeReference: 172972800, Version: 1}
+	data, err := bext.MarshalBinary()
+	assert.Nil(t, err)
+
+	f, err := Read(bytes.NewReader(testWave(
+		chunk{id: chunkBext, data: data},
+		chunk{id: "junk", data: []byte{0, 0, 0}},
+		chunk{id: chunkIXML, data: []byte(testIXML)},
+	)))
+	assert.Nil(t, err)
+	assert.Equal(t, int64(48000), f.SampleRate)
+	assert.Equal(t, &bext, f.Bext)
+	assert.Equal(t, []string{chunkFmt, chunkBext, "junk", chunkIXML, chunkData}, chunkIDs(f))
+
+	rate, err := f.Rate()
+	assert.Nil(t, err)
+	assert.Equal(t, timecode.R2398, rate)
+
+	tc, err := f.Timecode()
+	assert.Nil(t, err)
+	assert.Equal(t, "01:00:00:00", tc.String())
+	assert.Equal(t, timecode.R2398, tc.Rate())
+
+	// the bext TimeReference is used before the iXML time stamp
+	f.Bext.TimeReference = 0
+	tc, err = f.Timecode()
+	assert.Nil(t, err)
+	assert.Equal(t, "00:00:00:00", tc.String())
+
+	f.Bext = nil
+	tc, err = f.Timecode()
+	assert.Nil(t, err)
+	assert.Equal(t, "01:00:00:00", tc.String())
+
+	_, err = Read(bytes.NewReader([]byte("RIFF\x04\x00\x00\x00AVI ")))
+	assert.True(t, errors.Is(err, ErrNotWave))
+
+	_, err = Read(bytes.NewReader([]byte("RIFF")))
+	assert.True(t, errors.Is(err, ErrNotWave))
+
+	_, err = Read(bytes.NewReader(testWave(chunk{id: chunkBext, data: []byte{1, 2, 3}})))
+	assert.NotNil(t, err)
+
+	// a chunk size larger than the file is rejected before the chunk is allocated
+	_, err = Read(bytes.NewReader([]byte("RIFF\xff\xff\xff\xffWAVEiXML\xf0\xff\xff\x7f")))
+	assert.True(t, errors.Is(err, io.ErrUnexpectedEOF))
+
+	data = testWave(chunk{id: chunkIXML, data: []byte(testIXML)})
+	binary.LittleEndian.PutUint32(data[40:], 0x7ffffff0)
+	_, err = Read(bytes.NewReader(data))
+	assert.True(t, errors.Is(err, io.ErrUnexpectedEOF))
+
+	// a chunk must also end within the RIFF chunk
+	data = testWave(chunk{id: chunkIXML, data: []byte(testIXML)})
+	binary.LittleEndian.PutUint32(data[4:], 40)
+	_, err = Read(bytes.NewReader(data))
+	assert.True(t, errors.Is(err, io.ErrUnexpectedEOF))
+
+	// a RIFF size larger than the file is tolerated
+	data = testWave()
+	binary.LittleEndian.PutUint32(data[4:], 0xffffffff)
+	f, err = Read(bytes.NewReader(data))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{chunkFmt, chunkData}, chunkIDs(f))
+}
+
+func TestReadWithoutTimecode(t *testing.T) {
+	t.Parallel()
+
+	f, err := Read(bytes.NewReader(testWave()))
+	assert.Nil(t, err)
+	assert.Nil(t, f.Bext)
+	assert.Nil(t, f.IXML)
+
+	_, err = f.Timecode()
+	assert.True(t, errors.Is(err, ErrNoRate))
+
+	_, err = f.TimecodeWithRate(timecode.R25)
+	assert.True(t, errors.Is(err, ErrNoTimeReference))
+
+	f.Bext = &Bext{TimeReference: 48000 * 3600}
+	tc, err := f.TimecodeWithRate(timecode.R25)
+	assert.Nil(t, err)
+	assert.Equal(t, "01:00:00:00", tc.String())
+}
+
+func TestWrite(t *testing.T) {
+	t.Parallel()
+
+	src := testWave(chunk{id: "LIST", data: []byte("INFOtest")})
+	f, err := Read(bytes.NewReader(src))
+	assert.Nil(t, err)
+
+	tc, err := timecode.Parse(timecode.R2997DF, "01:00:00;00")
+	assert.Nil(t, err)
+	assert.Nil(t, f.SetTimecode(tc))
+	assert.Equal(t, uint64(172799828), f.Bext.TimeReference)
+
+	var buf bytes.Buffer
+	n, err := f.WriteTo(&buf)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(buf.Len()), n)
+	assert.Equal(t, uint32(buf.Len()-8), binary.LittleEndian.Uint32(buf.Bytes()[4:]))
+
+	back, err := Read(bytes.NewReader(buf.Bytes()))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{chunkBext, chunkFmt, "LIST", chunkIXML, chunkData}, chunkIDs(back))
+	assert.Equal(t, f.Bext, back.Bext)
+
+	result, err := back.Timecode()
+	assert.Nil(t, err)
+	assert.Equal(t, tc, result)
+
+	samples, sampleRate, err := back.IXML.TimeReference()
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(172799828), samples)
+	assert.Equal(t, int64(48000), sampleRate)
+
+	// the audio is copied from the source
+	assert.True(t, bytes.HasSuffix(buf.Bytes(), append([]byte("data\x05\x00\x00\x00"), append(testAudio, 0)...)))
+
+	// updating an existing chunk keeps its position
+	tc, err = timecode.Parse(timecode.R2997DF, "10:00:00;00")
+	assert.Nil(t, err)
+	assert.Nil(t, back.SetTimecode(tc))
+
+	var again bytes.Buffer
+	_, err = back.WriteTo(&again)
+	assert.Nil(t, err)
+
+	back, err = Read(bytes.NewReader(again.Bytes()))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{chunkBext, chunkFmt, "LIST", chunkIXML, chunkData}, chunkIDs(back))
+
+	result, err = back.Timecode()
+	assert.Nil(t, err)
+	assert.Equal(t, tc, result)
+
+	// removing the chunks
+	back.Bext = nil
+	back.IXML = nil
+	again.Reset()
+	_, err = back.WriteTo(&again)
+	assert.Nil(t, err)
+
+	back, err = Read(bytes.NewReader(again.Bytes()))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{chunkFmt, "LIST", chunkData}, chunkIDs(back))
+
+	assert.NotNil(t, back.SetTimecode(timecode.FromFrames(timecode.R25, -1)))
+}
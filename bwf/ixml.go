@@ -0,0 +1,222 @@
+package bwf
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	timecode "github.com/agorman/go-timecode/v2"
+)
+
+// The elements of the SPEED section of an iXML document used for timecode.
+const (
+	ixmlTimecodeRate = "TIMECODE_RATE"
+	ixmlTimecodeFlag = "TIMECODE_FLAG"
+	ixmlSamplesHi    = "TIMESTAMP_SAMPLES_SINCE_MIDNIGHT_HI"
+	ixmlSamplesLo    = "TIMESTAMP_SAMPLES_SINCE_MIDNIGHT_LO"
+	ixmlSampleRate   = "TIMESTAMP_SAMPLE_RATE"
+)
+
+// emptyIXML is the document NewIXML starts from.
+const emptyIXML = `<?xml version="1.0" encoding="UTF-8"?>
+<BWFXML>
+	<IXML_VERSION>1.61</IXML_VERSION>
+	<SPEED>
+	</SPEED>
+</BWFXML>
+`
+
+var (
+	// ixmlElements matches each SPEED element used for timecode including an empty element such as
+	// <TIMECODE_FLAG/>.
+	ixmlElements = map[string]*regexp.Regexp{}
+
+	// ixmlEmptyParents matches the empty elements that setElement adds elements to.
+	ixmlEmptyParents = map[string]*regexp.Regexp{}
+)
+
+func init() {
+	for _, name := range []string{ixmlTimecodeRate, ixmlTimecodeFlag, ixmlSamplesHi, ixmlSamplesLo, ixmlSampleRate} {
+		ixmlElements[name] = regexp.MustCompile(`(?s)<` + name + `\s*/>|<` + name + `>.*?</` + name + `>`)
+	}
+
+	for _, name := range []string{"BWFXML", "SPEED"} {
+		ixmlEmptyParents[name] = regexp.MustCompile(`<` + name + `\s*/>`)
+	}
+}
+
+// IXML is the XML document of an iXML chunk. Only the elements of the SPEED section used for timecode are
+// read and written. The rest of the document is kept as is so metadata such as the scene, take and
+// track list written by a recorder is preserved.
+type IXML struct {
+	data []byte
+}
+
+// ixmlDocument holds the elements of an iXML document used for timecode.
+type ixmlDocument struct {
+	XMLName xml.Name `xml:"BWFXML"`
+	Speed   struct {
+		TimecodeRate string `xml:"TIMECODE_RATE"`
+		TimecodeFlag string `xml:"TIMECODE_FLAG"`
+		SamplesHi    string `xml:"TIMESTAMP_SAMPLES_SINCE_MIDNIGHT_HI"`
+		SamplesLo    string `xml:"TIMESTAMP_SAMPLES_SINCE_MIDNIGHT_LO"`
+		SampleRate   string `xml:"TIMESTAMP_SAMPLE_RATE"`
+	} `xml:"SPEED"`
+}
+
+// NewIXML returns an iXML document without any timecode.
+func NewIXML() *IXML {
+	return &IXML{
+		data: []byte(emptyIXML),
+	}
+}
+
+// ParseIXML returns the iXML document held in data. Recorders often pad the chunk with NUL characters
+// which are removed. An error is returned if data isn't a BWFXML document.
+func ParseIXML(data []byte) (*IXML, error) {
+	x := &IXML{
+		data: bytes.TrimRight(data, "\x00"),
+	}
+
+	if _, err := x.document(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// Bytes returns the XML document.
+func (x *IXML) Bytes() []byte {
+	return x.data
+}
+
+// Rate returns the Rate from the TIMECODE_RATE and TIMECODE_FLAG elements such as 30000/1001 and DF. An
+// ErrNoRate error is returned if the document doesn't have a TIMECODE_RATE.
+func (x *IXML) Rate() (timecode.Rate, error) {
+	doc, err := x.document()
+	if err != nil {
+		return timecode.Rate{}, err
+	}
+
+	if doc.Speed.TimecodeRate == "" {
+		return timecode.Rate{}, ErrNoRate
+	}
+
+	return timecode.ParseRate(doc.Speed.TimecodeRate+" "+doc.Speed.TimecodeFlag, false)
+}
+
+// SetRate sets the TIMECODE_RATE and TIMECODE_FLAG elements to rate.
+func (x *IXML) SetRate(rate timecode.Rate) {
+	flag := "NDF"
+	if rate.DropFrame() {
+		flag = "DF"
+	}
+
+	x.setElement(ixmlTimecodeRate, fmt.Sprintf("%d/%d", rate.Num(), rate.Den()))
+	x.setElement(ixmlTimecodeFlag, flag)
+}
+
+// TimeReference returns the number of samples since midnight of the first sample of the file from the
+// TIMESTAMP_SAMPLES_SINCE_MIDNIGHT elements and the sample rate they're counted in from the
+// TIMESTAMP_SAMPLE_RATE element. The sample rate is 0 if the document doesn't have one. An
+// ErrNoTimeReference error is returned if the document doesn't have a time stamp.
+func (x *IXML) TimeReference() (uint64, int64, error) {
+	doc, err := x.document()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if doc.Speed.SamplesHi == "" && doc.Speed.SamplesLo == "" {
+		return 0, 0, ErrNoTimeReference
+	}
+
+	var parts [2]uint64
+	for i, value := range []string{doc.Speed.SamplesHi, doc.Speed.SamplesLo} {
+		if value == "" {
+			continue
+		}
+		parts[i], err = strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return 0, 0, fmt.Errorf("unable to parse iXML time stamp: %s: %w", value, err)
+		}
+	}
+
+	var sampleRate int64
+	if doc.Speed.SampleRate != "" {
+		sampleRate, err = strconv.ParseInt(doc.Speed.SampleRate, 10, 64)
+		if err != nil {
+			return 0, 0, fmt.Errorf("unable to parse iXML sample rate: %s: %w", doc.Speed.SampleRate, err)
+		}
+	}
+
+	return parts[0]<<32 | parts[1], sampleRate, nil
+}
+
+// SetTimeReference sets the TIMESTAMP_SAMPLES_SINCE_MIDNIGHT elements to samples and the
+// TIMESTAMP_SAMPLE_RATE element to sampleRate.
+func (x *IXML) SetTimeReference(samples uint64, sampleRate int64) {
+	x.setElement(ixmlSamplesHi, strconv.FormatUint(samples>>32, 10))
+	x.setElement(ixmlSamplesLo, strconv.FormatUint(samples&0xffffffff, 10))
+	x.setElement(ixmlSampleRate, strconv.FormatInt(sampleRate, 10))
+}
+
+// document decodes the elements of the document used for timecode.
+func (x *IXML) document() (ixmlDocument, error) {
+	var doc ixmlDocument
+
+	decoder := xml.NewDecoder(bytes.NewReader(x.data))
+	// the elements used for timecode are ASCII so documents in other single byte encodings are read as is
+	decoder.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) {
+		return input, nil
+	}
+
+	if err := decoder.Decode(&doc); err != nil {
+		return doc, fmt.Errorf("unable to parse iXML: %w", err)
+	}
+
+	doc.Speed.TimecodeRate = strings.TrimSpace(doc.Speed.TimecodeRate)
+	doc.Speed.TimecodeFlag = strings.TrimSpace(doc.Speed.TimecodeFlag)
+	doc.Speed.SamplesHi = strings.TrimSpace(doc.Speed.SamplesHi)
+	doc.Speed.SamplesLo = strings.TrimSpace(doc.Speed.SamplesLo)
+	doc.Speed.SampleRate = strings.TrimSpace(doc.Speed.SampleRate)
+	return doc, nil
+}
+
+// setElement replaces the element name with value. A missing element is added to the end of the SPEED
+// section which is added to the end of the document if it's missing.
+func (x *IXML) setElement(name, value string) {
+	element := "<" + name + ">" + value + "</" + name + ">"
+
+	if loc := ixmlElements[name].FindIndex(x.data); loc != nil {
+		x.data = splice(x.data, loc[0], loc[1], element)
+		return
+	}
+
+	x.expand("BWFXML")
+	x.expand("SPEED")
+
+	if i := bytes.Index(x.data, []byte("</SPEED>")); i >= 0 {
+		x.data = splice(x.data, i, i, element)
+		return
+	}
+
+	if i := bytes.LastIndex(x.data, []byte("</BWFXML>")); i >= 0 {
+		x.data = splice(x.data, i, i, "<SPEED>"+element+"</SPEED>")
+	}
+}
+
+// expand replaces the empty element <name/> with <name></name> so elements can be added to it.
+func (x *IXML) expand(name string) {
+	x.data = ixmlEmptyParents[name].ReplaceAll(x.data, []byte("<"+name+"></"+name+">"))
+}
+
+// splice returns data with data[start:end] replaced by s.
+func splice(data []byte, start, end int, s string) []byte {
+	result := make([]byte, 0, len(data)-(end-start)+len(s))
+	result = append(result, data[:start]...)
+	result = append(result, s...)
+	return append(result, data[end:]...)
+}
@@ -0,0 +1,107 @@
+package bwf
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	timecode "github.com/agorman/go-timecode/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+const testIXML = `<?xml version="1.0" encoding="UTF-8"?>
+<BWFXML>
+	<IXML_VERSION>1.52</IXML_VERSION>
+	<SCENE>12</SCENE>
+	<TAKE>3</TAKE>
+	<SPEED>
+		<MASTER_SPEED>24000/1001</MASTER_SPEED>
+		<TIMECODE_RATE>24000/1001</TIMECODE_RATE>
+		<TIMECODE_FLAG>NDF</TIMECODE_FLAG>
+		<TIMESTAMP_SAMPLES_SINCE_MIDNIGHT_HI>0</TIMESTAMP_SAMPLES_SINCE_MIDNIGHT_HI>
+		<TIMESTAMP_SAMPLES_SINCE_MIDNIGHT_LO>172972800</TIMESTAMP_SAMPLES_SINCE_MIDNIGHT_LO>
+		<TIMESTAMP_SAMPLE_RATE>48000</TIMESTAMP_SAMPLE_RATE>
+	</SPEED>
+</BWFXML>
+`
+
+func TestParseIXML(t *testing.T) {
+	t.Parallel()
+
+	x, err := ParseIXML([]byte(testIXML + "\x00\x00"))
+	assert.Nil(t, err)
+	assert.Equal(t, testIXML, string(x.Bytes()))
+
+	rate, err := x.Rate()
+	assert.Nil(t, err)
+	assert.Equal(t, timecode.R2398, rate)
+
+	samples, sampleRate, err := x.TimeReference()
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(172972800), samples)
+	assert.Equal(t, int64(48000), sampleRate)
+
+	_, err = ParseIXML([]byte("<BWFXML><SPEED>"))
+	assert.NotNil(t, err)
+
+	_, err = ParseIXML([]byte("<OTHER></OTHER>"))
+	assert.NotNil(t, err)
+
+	x, err = ParseIXML([]byte("<BWFXML><SPEED><TIMECODE_RATE>30000/1001</TIMECODE_RATE><TIMECODE_FLAG>DF</TIMECODE_FLAG></SPEED></BWFXML>"))
+	assert.Nil(t, err)
+
+	rate, err = x.Rate()
+	assert.Nil(t, err)
+	assert.Equal(t, timecode.R2997DF, rate)
+
+	_, _, err = x.TimeReference()
+	assert.True(t, errors.Is(err, ErrNoTimeReference))
+
+	_, err = NewIXML().Rate()
+	assert.True(t, errors.Is(err, ErrNoRate))
+}
+
+func TestSetIXML(t *testing.T) {
+	t.Parallel()
+
+	x, err := ParseIXML([]byte(testIXML))
+	assert.Nil(t, err)
+
+	x.SetRate(timecode.R2997DF)
+	x.SetTimeReference(1<<32+5, 96000)
+
+	rate, err := x.Rate()
+	assert.Nil(t, err)
+	assert.Equal(t, timecode.R2997DF, rate)
+
+	samples, sampleRate, err := x.TimeReference()
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1<<32+5), samples)
+	assert.Equal(t, int64(96000), sampleRate)
+
+	// the rest of the document is kept
+	assert.True(t, strings.Contains(string(x.Bytes()), "<SCENE>12</SCENE>"))
+	assert.True(t, strings.Contains(string(x.Bytes()), "<MASTER_SPEED>24000/1001</MASTER_SPEED>"))
+	assert.Equal(t, 1, strings.Count(string(x.Bytes()), "<TIMECODE_RATE>"))
+
+	for _, doc := range []string{
+		"<BWFXML><SCENE>1</SCENE></BWFXML>",
+		"<BWFXML><SPEED/></BWFXML>",
+		"<BWFXML/>",
+		"<BWFXML><SPEED><TIMECODE_FLAG/></SPEED></BWFXML>",
+	} {
+		x, err := ParseIXML([]byte(doc))
+		assert.Nil(t, err)
+
+		x.SetRate(timecode.R25)
+		x.SetTimeReference(1920, 48000)
+
+		rate, err := x.Rate()
+		assert.Nil(t, err, doc)
+		assert.Equal(t, timecode.R25, rate, doc)
+
+		samples, _, err := x.TimeReference()
+		assert.Nil(t, err, doc)
+		assert.Equal(t, uint64(1920), samples, doc)
+	}
+}
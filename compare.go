@@ -0,0 +1,103 @@
+package timecode
+
+import "fmt"
+
+// Diff returns the signed number of frames from b to a (a - b). An ErrRateMismatch error is
+// returned if a and b do not use the same Rate. An ErrOverflow or ErrUnderflow error is returned
+// if the difference doesn't fit in an int64. When the Rate uses Wrap24Hour the shortest distance
+// around the clock is returned so Diff of 00:00:10:00 and 23:59:50:00 is 10 seconds of frames
+// rather than almost -24 hours. The result is then between -12 and +12 hours.
+func Diff(a, b Timecode) (int64, error) {
+	if a.rate != b.rate {
+		return 0, fmt.Errorf("%w: %s and %s", ErrRateMismatch, a.rate, b.rate)
+	}
+
+	diff, err := subFrames(a.frames, b.frames)
+	if err != nil {
+		return 0, err
+	}
+
+	if a.rate.wrap == Wrap24Hour {
+		diff = a.rate.wrapDay(diff)
+		if day := a.rate.FramesPerDay(); diff > day/2 {
+			diff -= day
+		}
+	}
+
+	return diff, nil
+}
+
+// Compare returns -1 if tc is before other, 0 if they are equal and +1 if tc is after other. The
+// first field of a frame is before the second field and sub-frames are compared within a frame.
+// Compare panics with an ErrRateMismatch error if tc and other do not use the same Rate.
+func (tc Timecode) Compare(other Timecode) int {
+	if tc.rate != other.rate {
+		panic(fmt.Errorf("%w: %s and %s", ErrRateMismatch, tc.rate, other.rate))
+	}
+
+	switch {
+	case tc.frames < other.frames:
+		return -1
+	case tc.frames > other.frames:
+		return 1
+	case tc.secondField != other.secondField:
+		if other.secondField {
+			return -1
+		}
+		return 1
+	case tc.subFrame < other.subFrame:
+		return -1
+	case tc.subFrame > other.subFrame:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Before returns true if tc is before other. Before panics if tc and other do not use the same Rate.
+func (tc Timecode) Before(other Timecode) bool {
+	return tc.Compare(other) < 0
+}
+
+// After returns true if tc is after other. After panics if tc and other do not use the same Rate.
+func (tc Timecode) After(other Timecode) bool {
+	return tc.Compare(other) > 0
+}
+
+// Equal returns true if tc and other are the same frame, field and sub-frame. Equal panics if tc and
+// other do not use the same Rate.
+func (tc Timecode) Equal(other Timecode) bool {
+	return tc.Compare(other) == 0
+}
+
+// Min returns the earliest of the passed Timecodes. Min panics if the Timecodes do not all use the
+// same Rate.
+func Min(tc Timecode, others ...Timecode) Timecode {
+	for _, other := range others {
+		if other.Before(tc) {
+			tc = other
+		}
+	}
+
+	return tc
+}
+
+// Max returns the latest of the passed Timecodes. Max panics if the Timecodes do not all use the
+// same Rate.
+func Max(tc Timecode, others ...Timecode) Timecode {
+	for _, other := range others {
+		if other.After(tc) {
+			tc = other
+		}
+	}
+
+	return tc
+}
+
+// Timecodes attaches the methods of sort.Interface to []Timecode, sorting in increasing order.
+// Sorting panics if the Timecodes do not all use the same Rate.
+type Timecodes []Timecode
+
+func (t Timecodes) Len() int           { return len(t) }
+func (t Timecodes) Less(i, j int) bool { return t[i].Before(t[j]) }
+func (t Timecodes) Swap(i, j int)      { t[i], t[j] = t[j], t[i] }
@@ -0,0 +1,90 @@
+package timecode
+
+import (
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	a, err := Parse(R2997DF, "00:01:00;02")
+	assert.Nil(t, err)
+	b, err := Parse(R2997DF, "00:00:59;29")
+	assert.Nil(t, err)
+
+	diff, err := Diff(a, b)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), diff)
+
+	diff, err = Diff(b, a)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(-1), diff)
+
+	_, err = Diff(a, FromFrames(R2997, 0))
+	assert.True(t, errors.Is(err, ErrRateMismatch))
+}
+
+func TestCompare(t *testing.T) {
+	t.Parallel()
+
+	a := FromFrames(R25, 10)
+	b := FromFrames(R25, 20)
+
+	assert.Equal(t, -1, a.Compare(b))
+	assert.Equal(t, 1, b.Compare(a))
+	assert.Equal(t, 0, a.Compare(FromFrames(R25, 10)))
+
+	assert.True(t, a.Before(b))
+	assert.False(t, b.Before(a))
+	assert.True(t, b.After(a))
+	assert.False(t, a.After(b))
+	assert.True(t, a.Equal(FromFrames(R25, 10)))
+	assert.False(t, a.Equal(b))
+
+	assert.True(t, FromFrames(R25, -1).Before(FromFrames(R25, 0)))
+
+	assert.Panics(t, func() { a.Compare(FromFrames(R24, 10)) })
+	assert.Panics(t, func() { a.Equal(FromFrames(R2997, 10)) })
+	assert.Panics(t, func() { FromFrames(R2997, 10).Before(FromFrames(R2997DF, 10)) })
+}
+
+func TestMinMax(t *testing.T) {
+	t.Parallel()
+
+	a := FromFrames(R30, 5)
+	b := FromFrames(R30, -5)
+	c := FromFrames(R30, 50)
+
+	assert.Equal(t, a, Min(a))
+	assert.Equal(t, b, Min(a, b, c))
+	assert.Equal(t, c, Max(a, b, c))
+	assert.Equal(t, c, Max(c, a))
+
+	assert.Panics(t, func() { Min(a, FromFrames(R24, 0)) })
+}
+
+func TestSortTimecodes(t *testing.T) {
+	t.Parallel()
+
+	tcs := []Timecode{
+		FromFrames(R24, 100),
+		FromFrames(R24, -3),
+		FromFrames(R24, 0),
+		FromFrames(R24, 24),
+	}
+
+	sort.Sort(Timecodes(tcs))
+	assert.Equal(t, []Timecode{
+		FromFrames(R24, -3),
+		FromFrames(R24, 0),
+		FromFrames(R24, 24),
+		FromFrames(R24, 100),
+	}, tcs)
+
+	mixed := []Timecode{FromFrames(R24, 1), FromFrames(R25, 0)}
+	assert.Panics(t, func() { sort.Sort(Timecodes(mixed)) })
+}
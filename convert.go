@@ -0,0 +1,142 @@
+package timecode
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// ConvertMode describes what ConvertRate keeps the same when moving a Timecode to a different Rate.
+type ConvertMode int
+
+const (
+	// ConvertRealTime keeps the real elapsed time of the Timecode. This is the conversion used for standards
+	// conversion where 01:00:00;00 at 29.97 DF is 3599.9964 seconds and becomes 00:59:59:24 or 01:00:00:00
+	// at 25 fps depending on rounding.
+	ConvertRealTime ConvertMode = iota
+
+	// ConvertLabel keeps the hours, minutes and seconds of the label and scales the frames of the label to
+	// the new timeBase so 01:00:00;15 at 29.97 DF becomes 01:00:00:12 or 01:00:00:13 at 25 fps depending on
+	// rounding. Labels skipped by drop frame encoding are advanced to the next valid label.
+	ConvertLabel
+
+	// ConvertFrames keeps the frame count the same as FromFrames so 01:00:00:00 at 25 fps is 90000 frames
+	// and becomes 00:50:00:00 at 30 fps. The real time changes by the ratio of the frame rates.
+	ConvertFrames
+)
+
+// ConvertRate returns tc moved to the target Rate using mode. The second field of tc is half a frame
+// after the first field and the sub-frame of tc is a fraction of its frame. When the result falls between
+// two sub-frames of a target using sub-frames, two fields of an interlaced target, or two frames of any
+// other target, it's rounded using rounding. For ConvertLabel rounding applies to the frames of
+// the label so a negative Timecode is rounded the same as its positive label. An ErrOverflow or
+// ErrUnderflow error is returned if the result can't be represented and the result is wrapped or checked
+// according to the Wrap mode of target.
+func ConvertRate(tc Timecode, target Rate, mode ConvertMode, rounding Rounding) (Timecode, error) {
+	result := Timecode{
+		rate: target,
+	}
+
+	if tc.rate.den == 0 || target.den == 0 {
+		return result, fmt.Errorf("unable to convert timecode between rates %s and %s", tc.rate, target)
+	}
+
+	units := big.NewInt(convertUnits(target))
+
+	var position *big.Int
+	var err error
+	switch mode {
+	case ConvertRealTime:
+		exact := tc.position()
+		exact.Mul(exact, big.NewRat(tc.rate.den, tc.rate.num))
+		exact.Mul(exact, target.Ratio())
+		position = round(exact.Mul(exact, new(big.Rat).SetInt(units)), rounding)
+	case ConvertLabel:
+		position, err = convertLabel(tc, target, rounding)
+	case ConvertFrames:
+		exact := tc.position()
+		position = round(exact.Mul(exact, new(big.Rat).SetInt(units)), rounding)
+	default:
+		return result, fmt.Errorf("unknown convert mode: %d", mode)
+	}
+	if err != nil {
+		return result, err
+	}
+
+	// split the position into whole frames and the sub-frame or field of the frame
+	frames, remainder := new(big.Int).DivMod(position, units, new(big.Int))
+	if !frames.IsInt64() {
+		if frames.Sign() < 0 {
+			return result, fmt.Errorf("%w: %s can not be converted to %s", ErrUnderflow, tc, target)
+		}
+		return result, fmt.Errorf("%w: %s can not be converted to %s", ErrOverflow, tc, target)
+	}
+
+	result.frames, err = target.bound(frames.Int64())
+	if err != nil {
+		return result, err
+	}
+	switch {
+	case target.subFrames > 0:
+		result.subFrame = remainder.Int64()
+	case target.scan == ScanModeInterlaced:
+		result.secondField = remainder.Sign() > 0
+	}
+
+	return result, nil
+}
+
+// convertUnits returns the number of positions ConvertRate can represent in a frame of target.
+func convertUnits(target Rate) int64 {
+	if target.subFrames > 0 {
+		return target.subFrames
+	}
+	if target.scan == ScanModeInterlaced {
+		return 2
+	}
+	return 1
+}
+
+// convertLabel returns the position of target, in units of convertUnits, with the same hours, minutes and
+// seconds label as tc.
+func convertLabel(tc Timecode, target Rate, rounding Rounding) (*big.Int, error) {
+	hours, minutes, seconds, frame := tc.parts()
+	units := convertUnits(target)
+	perSecond := target.timeBase * units
+
+	exact := new(big.Rat).SetFrac(new(big.Int).SetUint64(frame), big.NewInt(1))
+	if tc.secondField {
+		exact.Add(exact, big.NewRat(1, 2))
+	}
+	if subFrame := tc.SubFrame(); subFrame > 0 {
+		exact.Add(exact, big.NewRat(int64(subFrame), tc.rate.subFrames))
+	}
+	exact.Mul(exact, big.NewRat(perSecond, tc.rate.timeBase))
+	scaled := round(exact, rounding).Int64()
+
+	// rounding up the last frame of a second carries into the next second
+	if scaled >= perSecond {
+		scaled -= perSecond
+		total := (hours*60+minutes)*60 + seconds + 1
+		hours, minutes, seconds = total/3600, total/60%60, total%60
+	}
+	frame, remainder := uint64(scaled/units), scaled%units
+
+	if isDroppedLabel(target, minutes, seconds, frame) {
+		frame, remainder = uint64(target.dropFrames()), 0
+	}
+
+	frames, err := labelToFrames(target, hours, minutes, seconds, frame)
+	if err != nil {
+		if tc.Negative() {
+			return nil, fmt.Errorf("%w: %s can not be converted to %s", ErrUnderflow, tc, target)
+		}
+		return nil, fmt.Errorf("%w: %s can not be converted to %s", ErrOverflow, tc, target)
+	}
+
+	position := new(big.Int).Mul(big.NewInt(frames), big.NewInt(units))
+	position.Add(position, big.NewInt(remainder))
+	if tc.Negative() {
+		position.Neg(position)
+	}
+	return position, nil
+}
@@ -0,0 +1,267 @@
+package timecode
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var predefinedRates = []Rate{R2997, R2997DF, R30, R5994, R5994DF, R60, R25, R50, R2398, R24, R120, R240}
+
+func TestConvertRateRealTime(t *testing.T) {
+	t.Parallel()
+
+	tc, err := Parse(R2997DF, "01:00:00;00")
+	assert.Nil(t, err)
+
+	nearest, err := ConvertRate(tc, R25, ConvertRealTime, RoundNearest)
+	assert.Nil(t, err)
+	assert.Equal(t, "01:00:00:00", nearest.String())
+	assert.Equal(t, R25, nearest.Rate())
+
+	floor, err := ConvertRate(tc, R25, ConvertRealTime, RoundFloor)
+	assert.Nil(t, err)
+	assert.Equal(t, "00:59:59:24", floor.String())
+
+	tc, err = Parse(R25, "01:00:00:00")
+	assert.Nil(t, err)
+	converted, err := ConvertRate(tc, R2997DF, ConvertRealTime, RoundNearest)
+	assert.Nil(t, err)
+	assert.Equal(t, "01:00:00;00", converted.String())
+	converted, err = ConvertRate(tc, R2997, ConvertRealTime, RoundNearest)
+	assert.Nil(t, err)
+	assert.Equal(t, "00:59:56:12", converted.String())
+
+	tc, err = Parse(R25, "-00:00:01:01")
+	assert.Nil(t, err)
+	converted, err = ConvertRate(tc, R30, ConvertRealTime, RoundFloor)
+	assert.Nil(t, err)
+	assert.Equal(t, "-00:00:01:02", converted.String())
+	converted, err = ConvertRate(tc, R30, ConvertRealTime, RoundCeil)
+	assert.Nil(t, err)
+	assert.Equal(t, "-00:00:01:01", converted.String())
+
+	for _, source := range predefinedRates {
+		for _, target := range predefinedRates {
+			for _, frames := range []int64{0, 1, 1799, 17982, 107892, 2589407, -4321} {
+				tc := FromFrames(source, frames)
+
+				converted, err := ConvertRate(tc, target, ConvertRealTime, RoundNearest)
+				assert.Nil(t, err)
+
+				// the real time differs by at most half a frame of the target
+				assert.LessOrEqual(t, math.Abs(converted.Seconds()-tc.Seconds()), 0.5/target.FPS()+1e-9)
+
+				// converting to a faster rate and back doesn't lose frames
+				if target.Ratio().Cmp(source.Ratio()) >= 0 {
+					back, err := ConvertRate(converted, source, ConvertRealTime, RoundNearest)
+					assert.Nil(t, err)
+					assert.Equal(t, tc, back, "%s to %s", source, target)
+				}
+			}
+		}
+	}
+}
+
+func TestConvertRateLabel(t *testing.T) {
+	t.Parallel()
+
+	tc, err := Parse(R2997DF, "01:00:00;15")
+	assert.Nil(t, err)
+
+	converted, err := ConvertRate(tc, R25, ConvertLabel, RoundNearest)
+	assert.Nil(t, err)
+	assert.Equal(t, "01:00:00:13", converted.String())
+
+	converted, err = ConvertRate(tc, R25, ConvertLabel, RoundFloor)
+	assert.Nil(t, err)
+	assert.Equal(t, "01:00:00:12", converted.String())
+
+	tc, err = Parse(R30, "00:00:59:29")
+	assert.Nil(t, err)
+	converted, err = ConvertRate(tc, R25, ConvertLabel, RoundCeil)
+	assert.Nil(t, err)
+	assert.Equal(t, "00:01:00:00", converted.String())
+
+	converted, err = ConvertRate(tc, R2997DF, ConvertLabel, RoundCeil)
+	assert.Nil(t, err)
+	assert.Equal(t, "00:00:59;29", converted.String())
+
+	tc, err = Parse(R25, "00:01:00:00")
+	assert.Nil(t, err)
+	converted, err = ConvertRate(tc, R2997DF, ConvertLabel, RoundNearest)
+	assert.Nil(t, err)
+	assert.Equal(t, "00:01:00;02", converted.String())
+
+	tc, err = Parse(R25, "-10:00:00:10")
+	assert.Nil(t, err)
+	converted, err = ConvertRate(tc, R2398, ConvertLabel, RoundNearest)
+	assert.Nil(t, err)
+	assert.Equal(t, "-10:00:00:10", converted.String())
+
+	for _, source := range predefinedRates {
+		for _, target := range predefinedRates {
+			for _, frames := range []int64{0, 1, 1799, 17982, 107892, 2589407, -4321} {
+				tc := FromFrames(source, frames)
+
+				converted, err := ConvertRate(tc, target, ConvertLabel, RoundFloor)
+				assert.Nil(t, err)
+				assert.Equal(t, tc.Negative(), converted.Negative())
+				assert.Equal(t, tc.Hour(), converted.Hour())
+				assert.Equal(t, tc.Minute(), converted.Minute())
+				assert.Equal(t, tc.Second(), converted.Second())
+
+				if source.timeBase == target.timeBase && !target.DropFrame() {
+					assert.Equal(t, tc.Frame(), converted.Frame())
+				}
+			}
+		}
+	}
+}
+
+func TestConvertRateFrames(t *testing.T) {
+	t.Parallel()
+
+	tc, err := Parse(R25, "01:00:00:00")
+	assert.Nil(t, err)
+
+	converted, err := ConvertRate(tc, R30, ConvertFrames, RoundNearest)
+	assert.Nil(t, err)
+	assert.Equal(t, "00:50:00:00", converted.String())
+
+	for _, source := range predefinedRates {
+		for _, target := range predefinedRates {
+			tc := FromFrames(source, 2589407)
+
+			converted, err := ConvertRate(tc, target, ConvertFrames, RoundNearest)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.Frames(), converted.Frames())
+			assert.Equal(t, target, converted.Rate())
+		}
+	}
+}
+
+func TestConvertRateFields(t *testing.T) {
+	t.Parallel()
+
+	r50i := R25.WithScanMode(ScanModeInterlaced)
+
+	// the second field is half a frame after the first
+	tc, err := FromFields(r50i, 1)
+	assert.Nil(t, err)
+	converted, err := ConvertRate(tc, R50, ConvertRealTime, RoundFloor)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), converted.Frames())
+
+	converted, err = ConvertRate(FromFrames(R50, 51), r50i, ConvertRealTime, RoundFloor)
+	assert.Nil(t, err)
+	assert.Equal(t, "00:00:01.00", converted.String())
+
+	tc, err = Parse(r50i, "00:00:00.12")
+	assert.Nil(t, err)
+	converted, err = ConvertRate(tc, R50, ConvertLabel, RoundFloor)
+	assert.Nil(t, err)
+	assert.Equal(t, "00:00:00:25", converted.String())
+
+	converted, err = ConvertRate(tc, R25, ConvertFrames, RoundFloor)
+	assert.Nil(t, err)
+	assert.Equal(t, "00:00:00:12", converted.String())
+	converted, err = ConvertRate(tc, R25, ConvertFrames, RoundCeil)
+	assert.Nil(t, err)
+	assert.Equal(t, "00:00:00:13", converted.String())
+
+	// converting an interlaced rate to itself keeps every field
+	for fields := int64(-101); fields <= 101; fields += 3 {
+		tc, err := FromFields(r50i, fields)
+		assert.Nil(t, err)
+
+		for _, mode := range []ConvertMode{ConvertRealTime, ConvertLabel, ConvertFrames} {
+			converted, err := ConvertRate(tc, r50i, mode, RoundNearest)
+			assert.Nil(t, err)
+			assert.Equal(t, tc, converted, "%s mode %d", tc, mode)
+		}
+	}
+}
+
+func TestConvertRateSubFrames(t *testing.T) {
+	t.Parallel()
+
+	r2997sf := R2997.WithSubFrames(SubFrames80)
+
+	tc, err := Parse(r2997sf, "00:00:00:00.79")
+	assert.Nil(t, err)
+	converted, err := ConvertRate(tc, R2997, ConvertRealTime, RoundNearest)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), converted.Frames())
+
+	converted, err = ConvertRate(tc, R2997.WithSubFrames(SubFrames100), ConvertRealTime, RoundNearest)
+	assert.Nil(t, err)
+	assert.Equal(t, "00:00:00:00.99", converted.String())
+
+	tc, err = Parse(r2997sf, "-00:00:00:00.20")
+	assert.Nil(t, err)
+	converted, err = ConvertRate(tc, R2997.WithSubFrames(SubFrames100), ConvertRealTime, RoundNearest)
+	assert.Nil(t, err)
+	assert.Equal(t, "-00:00:00:00.25", converted.String())
+
+	tc, err = Parse(r2997sf, "01:00:00:29.40")
+	assert.Nil(t, err)
+	converted, err = ConvertRate(tc, R30.WithSubFrames(SubFrames80), ConvertLabel, RoundNearest)
+	assert.Nil(t, err)
+	assert.Equal(t, "01:00:00:29.40", converted.String())
+	converted, err = ConvertRate(tc, R25, ConvertLabel, RoundNearest)
+	assert.Nil(t, err)
+	assert.Equal(t, "01:00:01:00", converted.String())
+
+	converted, err = ConvertRate(tc, R2997, ConvertFrames, RoundFloor)
+	assert.Nil(t, err)
+	assert.Equal(t, tc.Frames(), converted.Frames())
+
+	// converting a rate with sub-frames to itself keeps every sub-frame
+	for subFrames := int64(-401); subFrames <= 401; subFrames += 7 {
+		tc, err := FromSubFrames(r2997sf, subFrames)
+		assert.Nil(t, err)
+
+		for _, mode := range []ConvertMode{ConvertRealTime, ConvertLabel, ConvertFrames} {
+			converted, err := ConvertRate(tc, r2997sf, mode, RoundNearest)
+			assert.Nil(t, err)
+			assert.Equal(t, tc, converted, "%s mode %d", tc, mode)
+		}
+	}
+}
+
+func TestConvertRateErrors(t *testing.T) {
+	t.Parallel()
+
+	tc := FromFrames(R240, math.MaxInt64)
+	_, err := ConvertRate(tc, R2997, ConvertRealTime, RoundNearest)
+	assert.Nil(t, err)
+
+	tc = FromFrames(R24, math.MaxInt64)
+	_, err = ConvertRate(tc, R240, ConvertRealTime, RoundNearest)
+	assert.True(t, errors.Is(err, ErrOverflow))
+
+	tc = FromFrames(R24, math.MinInt64+1)
+	_, err = ConvertRate(tc, R240, ConvertRealTime, RoundNearest)
+	assert.True(t, errors.Is(err, ErrUnderflow))
+
+	_, err = ConvertRate(tc, R240, ConvertLabel, RoundNearest)
+	assert.True(t, errors.Is(err, ErrUnderflow))
+
+	tc, err = Parse(R25, "25:00:00:00")
+	assert.Nil(t, err)
+	_, err = ConvertRate(tc, R2997.WithWrap(WrapError), ConvertRealTime, RoundNearest)
+	assert.True(t, errors.Is(err, ErrOverflow))
+
+	wrapped, err := ConvertRate(tc, R2997.WithWrap(Wrap24Hour), ConvertLabel, RoundNearest)
+	assert.Nil(t, err)
+	assert.Equal(t, "01:00:00:00", wrapped.String())
+
+	_, err = ConvertRate(tc, Rate{}, ConvertFrames, RoundNearest)
+	assert.NotNil(t, err)
+
+	_, err = ConvertRate(tc, R30, ConvertMode(9), RoundNearest)
+	assert.NotNil(t, err)
+}
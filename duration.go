@@ -0,0 +1,93 @@
+package timecode
+
+import (
+	"math"
+	"math/big"
+	"time"
+)
+
+// Rounding describes how a value that falls between two frames is rounded to a whole frame.
+type Rounding int
+
+const (
+	// RoundFloor rounds toward negative infinity so a value is rounded to the frame that contains it.
+	RoundFloor Rounding = iota
+
+	// RoundNearest rounds to the nearest frame with values halfway between frames rounded away from zero.
+	RoundNearest
+
+	// RoundCeil rounds toward positive infinity.
+	RoundCeil
+)
+
+// FromDuration returns a Timecode based on the passed rate and real elapsed duration. The duration is
+// converted to frames using the exact frame rate of the Rate and rounded to a whole frame using rounding.
+// The result is wrapped when the Rate uses Wrap24Hour and an ErrOverflow or ErrUnderflow error is returned
+// when the Rate uses WrapError and the duration falls outside of 24 hours.
+func FromDuration(rate Rate, d time.Duration, rounding Rounding) (Timecode, error) {
+	frames := big.NewRat(int64(d), int64(time.Second))
+	frames.Mul(frames, rate.Ratio())
+
+	bounded, err := rate.bound(round(frames, rounding).Int64())
+	if err != nil {
+		return Timecode{rate: rate}, err
+	}
+
+	return Timecode{rate: rate, frames: bounded}, nil
+}
+
+// Duration returns the real elapsed time of the Timecode as a time.Duration rounded to the nearest
+// nanosecond. Timecodes too large to be represented as a time.Duration return the maximum (or minimum)
+// time.Duration.
+func (tc Timecode) Duration() time.Duration {
+	d := tc.position()
+	d.Mul(d, big.NewRat(tc.rate.den*int64(time.Second), tc.rate.num))
+
+	return saturatedDuration(round(d, RoundNearest))
+}
+
+// FrameDuration returns the real elapsed time of a single frame rounded to the nearest nanosecond. For
+// example 33.366667ms for 29.97. Durations of many frames should be computed with Timecode.Duration to
+// avoid accumulating the rounding error.
+func (r Rate) FrameDuration() time.Duration {
+	return FromFrames(r, 1).Duration()
+}
+
+// round rounds r to a whole number using rounding.
+func round(r *big.Rat, rounding Rounding) *big.Int {
+	// Div rounds toward negative infinity for a positive divisor
+	floor := new(big.Int).Div(r.Num(), r.Denom())
+	if r.IsInt() {
+		return floor
+	}
+
+	switch rounding {
+	case RoundCeil:
+		return floor.Add(floor, big.NewInt(1))
+	case RoundNearest:
+		fraction := new(big.Rat).Sub(r, new(big.Rat).SetInt(floor))
+		switch fraction.Cmp(big.NewRat(1, 2)) {
+		case 1:
+			return floor.Add(floor, big.NewInt(1))
+		case 0:
+			if r.Sign() > 0 {
+				return floor.Add(floor, big.NewInt(1))
+			}
+		}
+		return floor
+	default:
+		return floor
+	}
+}
+
+// saturatedDuration converts i to a time.Duration clamping it to the range of a time.Duration.
+func saturatedDuration(i *big.Int) time.Duration {
+	if !i.IsInt64() {
+		if i.Sign() < 0 {
+			return math.MinInt64
+		}
+		return math.MaxInt64
+	}
+
+	return time.Duration(i.Int64())
+}
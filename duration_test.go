@@ -0,0 +1,98 @@
+package timecode
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDuration(t *testing.T) {
+	t.Parallel()
+
+	tc, err := Parse(R30, "01:30:12:15")
+	assert.Nil(t, err)
+	assert.Equal(t, 90*time.Minute+12*time.Second+500*time.Millisecond, tc.Duration())
+
+	tc, err = Parse(R2997DF, "01:30:12;15")
+	assert.Nil(t, err)
+	assert.Equal(t, 5412507100*time.Microsecond, tc.Duration())
+
+	tc, err = Parse(R2398, "24:00:00:00")
+	assert.Nil(t, err)
+	assert.Equal(t, 86486400*time.Millisecond, tc.Duration())
+
+	tc, err = Parse(R25, "-00:00:01:12")
+	assert.Nil(t, err)
+	assert.Equal(t, -1480*time.Millisecond, tc.Duration())
+
+	assert.Equal(t, time.Duration(33366667), FromFrames(R2997, 1).Duration())
+	assert.Equal(t, time.Duration(math.MaxInt64), FromFrames(R30, math.MaxInt64).Duration())
+	assert.Equal(t, time.Duration(math.MinInt64), FromFrames(R30, math.MinInt64).Duration())
+}
+
+func TestFromDuration(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		rate     Rate
+		d        time.Duration
+		rounding Rounding
+		expected string
+	}{
+		{R30, 10 * time.Second, RoundFloor, "00:00:10:00"},
+		{R30, 10 * time.Second, RoundNearest, "00:00:10:00"},
+		{R30, 10 * time.Second, RoundCeil, "00:00:10:00"},
+		{R25, 30 * time.Millisecond, RoundFloor, "00:00:00:00"},
+		{R25, 30 * time.Millisecond, RoundNearest, "00:00:00:01"},
+		{R25, 30 * time.Millisecond, RoundCeil, "00:00:00:01"},
+		{R25, 10 * time.Millisecond, RoundNearest, "00:00:00:00"},
+		{R25, 20 * time.Millisecond, RoundNearest, "00:00:00:01"},
+		{R25, -20 * time.Millisecond, RoundNearest, "-00:00:00:01"},
+		{R25, -10 * time.Millisecond, RoundFloor, "-00:00:00:01"},
+		{R25, -10 * time.Millisecond, RoundCeil, "00:00:00:00"},
+		{R2997DF, 5412507100 * time.Microsecond, RoundFloor, "01:30:12;15"},
+		{R2997DF, time.Minute, RoundFloor, "00:00:59;28"},
+		{R2997DF, time.Minute, RoundCeil, "00:00:59;29"},
+		{R2398, 86486400 * time.Millisecond, RoundFloor, "24:00:00:00"},
+	}
+
+	for _, test := range tests {
+		tc, err := FromDuration(test.rate, test.d, test.rounding)
+		assert.Nil(t, err, test.d.String())
+		assert.Equal(t, test.expected, tc.String(), test.d.String())
+	}
+
+	// every frame must survive a round trip through a duration
+	for _, rate := range []Rate{R2997, R2997DF, R5994DF, R2398, R24} {
+		for frames := int64(-50000); frames < 50000; frames += 331 {
+			d := FromFrames(rate, frames).Duration()
+			tc, err := FromDuration(rate, d, RoundNearest)
+			assert.Nil(t, err)
+			assert.Equal(t, frames, tc.Frames())
+		}
+	}
+
+	tc, err := FromDuration(R30.WithWrap(Wrap24Hour), 25*time.Hour, RoundFloor)
+	assert.Nil(t, err)
+	assert.Equal(t, "01:00:00:00", tc.String())
+
+	_, err = FromDuration(R30.WithWrap(WrapError), 25*time.Hour, RoundFloor)
+	assert.True(t, errors.Is(err, ErrOverflow))
+
+	_, err = FromDuration(R30.WithWrap(WrapError), -5*time.Second, RoundFloor)
+	assert.True(t, errors.Is(err, ErrUnderflow))
+}
+
+func TestFrameDuration(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, time.Second/30, R30.FrameDuration())
+	assert.Equal(t, 40*time.Millisecond, R25.FrameDuration())
+	assert.Equal(t, time.Duration(33366667), R2997.FrameDuration())
+	assert.Equal(t, time.Duration(33366667), R2997DF.FrameDuration())
+	assert.Equal(t, time.Duration(41708333), R2398.FrameDuration())
+	assert.Equal(t, time.Duration(16683333), R5994.FrameDuration())
+}
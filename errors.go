@@ -0,0 +1,23 @@
+package timecode
+
+import "errors"
+
+var (
+	// ErrRateMismatch is returned or panicked with when two Timecodes with different Rates are
+	// compared or measured against each other.
+	ErrRateMismatch = errors.New("timecode rates do not match")
+
+	// ErrOverflow is returned when the result of an operation is too large to be represented by a Timecode.
+	ErrOverflow = errors.New("timecode overflow")
+
+	// ErrUnderflow is returned when the result of an operation is too small to be represented by a Timecode.
+	ErrUnderflow = errors.New("timecode underflow")
+
+	// ErrInvalidDropFrame is returned when drop frame encoding is requested for a rate that SMPTE doesn't
+	// define drop frame for. Drop frame is only defined for 29.97 and 59.94.
+	ErrInvalidDropFrame = errors.New("drop frame is not defined for rate")
+
+	// ErrDroppedFrame is returned when parsing a drop frame label that drop frame encoding skips such as
+	// 00:01:00;00 at 29.97 DF.
+	ErrDroppedFrame = errors.New("timecode label is skipped by drop frame encoding")
+)
@@ -0,0 +1,136 @@
+package timecode
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// errInterlacedSubFrames is returned or panicked with when a Rate would be both interlaced and divided into
+// sub-frames.
+var errInterlacedSubFrames = errors.New("an interlaced rate can't use sub-frames")
+
+// ScanMode describes whether the frames of a Rate are progressive or made of two interlaced fields.
+type ScanMode int
+
+const (
+	// ScanModeProgressive is a Rate where each frame is a single picture. This is the default.
+	ScanModeProgressive ScanMode = iota
+
+	// ScanModeInterlaced is a Rate where each frame is made of two fields such as 50i and 59.94i. Timecodes of
+	// an interlaced Rate identify the first or second field of a frame.
+	ScanModeInterlaced
+)
+
+// WithScanMode returns a copy of the Rate using the passed ScanMode. Timecodes with the same frame rate
+// but different ScanModes use different Rates and can't be compared. WithScanMode panics if scan is
+// ScanModeInterlaced and the Rate uses sub-frames.
+func (r Rate) WithScanMode(scan ScanMode) Rate {
+	if scan == ScanModeInterlaced && r.subFrames > 0 {
+		panic(fmt.Errorf("%w: %s", errInterlacedSubFrames, r))
+	}
+
+	r.scan = scan
+	return r
+}
+
+// ScanMode returns the ScanMode of the Rate.
+func (r Rate) ScanMode() ScanMode {
+	return r.scan
+}
+
+// Interlaced returns true if the Rate uses ScanModeInterlaced.
+func (r Rate) Interlaced() bool {
+	return r.scan == ScanModeInterlaced
+}
+
+// FromFields returns a Timecode based on the passed interlaced rate and number of fields. There are two
+// fields in each frame so 51 fields is the second field of frame 25. Negative fields count back from the
+// first field of frame 0 so -1 is the second field of frame -1. That's half a frame before 00:00:00:00
+// and is labeled -00:00:00.00, the second field of 00:00:00:00 with a minus sign. An error is returned if
+// the Rate isn't interlaced. Like FromFrames the result is wrapped when the Rate uses Wrap24Hour.
+func FromFields(rate Rate, fields int64) (Timecode, error) {
+	if rate.scan != ScanModeInterlaced {
+		return Timecode{rate: rate}, fmt.Errorf("fields require an interlaced rate but got: %s", rate)
+	}
+
+	frames, err := rate.bound(floorDiv(fields, 2))
+	if err != nil {
+		return Timecode{rate: rate}, err
+	}
+
+	return Timecode{rate: rate, frames: frames, secondField: floorMod(fields, 2) == 1}, nil
+}
+
+// Fields returns the number of fields from the first field of frame 0 to the field of the Timecode. An
+// ErrOverflow or ErrUnderflow error is returned if the fields don't fit in an int64.
+func (tc Timecode) Fields() (int64, error) {
+	if tc.frames > math.MaxInt64/2 {
+		return 0, fmt.Errorf("%w: %s has too many fields", ErrOverflow, tc)
+	}
+	if tc.frames < math.MinInt64/2 {
+		return 0, fmt.Errorf("%w: %s has too many fields", ErrUnderflow, tc)
+	}
+
+	fields := tc.frames * 2
+	if tc.secondField {
+		fields++
+	}
+	return fields, nil
+}
+
+// Field returns 1 for the first field of the frame and 2 for the second field. Timecodes of progressive
+// Rates are always field 1.
+func (tc Timecode) Field() int {
+	if tc.secondField {
+		return 2
+	}
+	return 1
+}
+
+// WithField returns a copy of the Timecode with the same label at field 1 or 2. An error is returned if
+// field isn't 1 or 2 or field is 2 and the Rate isn't interlaced.
+func (tc Timecode) WithField(field int) (Timecode, error) {
+	switch {
+	case field != 1 && field != 2:
+		return tc, fmt.Errorf("field must be 1 or 2 got: %d", field)
+	case field == 2 && tc.rate.scan != ScanModeInterlaced:
+		return tc, fmt.Errorf("fields require an interlaced rate but got: %s", tc.rate)
+	}
+
+	if tc.Negative() {
+		label := Timecode{rate: tc.rate, frames: int64(tc.absFrames()), secondField: field == 2}
+		return label.negate(), nil
+	}
+
+	tc.secondField = field == 2
+	return tc, nil
+}
+
+// AddFields adds the fields to the Timecode and returns a new Timecode as the result. Negative fields move
+// the Timecode backwards. An error is returned if the Rate isn't interlaced and an ErrOverflow or
+// ErrUnderflow error is returned if the result can't be represented. The result is wrapped or checked
+// according to the Wrap mode of the Rate.
+func (tc Timecode) AddFields(fields int64) (Timecode, error) {
+	if tc.rate.scan != ScanModeInterlaced {
+		return tc, fmt.Errorf("fields require an interlaced rate but got: %s", tc.rate)
+	}
+
+	// carry a second field plus an odd number of fields into the next frame
+	frames := floorDiv(fields, 2)
+	secondField := tc.secondField
+	if floorMod(fields, 2) == 1 {
+		if secondField {
+			frames++
+		}
+		secondField = !secondField
+	}
+
+	result, err := tc.Add(frames)
+	if err != nil {
+		return result, err
+	}
+
+	result.secondField = secondField
+	return result, nil
+}
@@ -0,0 +1,267 @@
+package timecode
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	r50i   = R25.WithScanMode(ScanModeInterlaced)
+	r5994i = R2997DF.WithScanMode(ScanModeInterlaced)
+)
+
+func TestInterlacedRates(t *testing.T) {
+	t.Parallel()
+
+	rate, err := ParseRate("50i", false)
+	assert.Nil(t, err)
+	assert.True(t, rate.Interlaced())
+	assert.Equal(t, ScanModeInterlaced, rate.ScanMode())
+	assert.Equal(t, r50i, rate)
+	assert.Equal(t, "50/1i", rate.String())
+	assert.Equal(t, 25.0, rate.FPS())
+
+	rate, err = ParseRate(rate.String(), false)
+	assert.Nil(t, err)
+	assert.Equal(t, r50i, rate)
+
+	rate, err = ParseRate("59.94i DF", false)
+	assert.Nil(t, err)
+	assert.Equal(t, r5994i, rate)
+	assert.Equal(t, "60000/1001i DF", rate.String())
+
+	rate, err = ParseRate("25p", false)
+	assert.Nil(t, err)
+	assert.False(t, rate.Interlaced())
+	assert.Equal(t, ScanModeProgressive, rate.ScanMode())
+	assert.Equal(t, "25/1", rate.String())
+}
+
+func TestFields(t *testing.T) {
+	t.Parallel()
+
+	tc, err := FromFields(r50i, 51)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(25), tc.Frames())
+	assert.Equal(t, 2, tc.Field())
+	assert.Equal(t, "00:00:01.00", tc.String())
+	assert.Equal(t, 1020*time.Millisecond, tc.Duration())
+
+	fields, err := tc.Fields()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(51), fields)
+
+	tc, err = FromFields(r50i, -1)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(-1), tc.Frames())
+	assert.Equal(t, 2, tc.Field())
+	assert.Equal(t, "-00:00:00.00", tc.String())
+
+	tc, err = FromFields(r50i, -3)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(-2), tc.Frames())
+	assert.Equal(t, "-00:00:00.01", tc.String())
+
+	tc, err = Parse(r50i, "-00:00:00.01")
+	assert.Nil(t, err)
+	fields, err = tc.Fields()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(-3), fields)
+
+	tc, err = tc.WithField(1)
+	assert.Nil(t, err)
+	assert.Equal(t, "-00:00:00:01", tc.String())
+	tc, err = tc.WithField(2)
+	assert.Nil(t, err)
+	assert.Equal(t, "-00:00:00.01", tc.String())
+
+	tc, err = ParseWithLayout(r50i, LayoutSMPTE, "-00:00:00.00")
+	assert.Nil(t, err)
+	half, err := FromFields(r50i, -1)
+	assert.Nil(t, err)
+	assert.Equal(t, half, tc)
+
+	// labels are symmetric around zero
+	for fields := int64(-101); fields <= 101; fields += 3 {
+		tc, err := FromFields(r50i, fields)
+		assert.Nil(t, err)
+		opposite, err := FromFields(r50i, -fields)
+		assert.Nil(t, err)
+		if fields < 0 {
+			assert.Equal(t, "-"+opposite.String(), tc.String())
+		}
+
+		parsed, err := Parse(r50i, tc.String())
+		assert.Nil(t, err)
+		assert.Equal(t, tc, parsed)
+	}
+
+	for fields := int64(-10); fields < 10; fields++ {
+		tc, err := FromFields(r5994i, fields)
+		assert.Nil(t, err)
+
+		back, err := tc.Fields()
+		assert.Nil(t, err)
+		assert.Equal(t, fields, back)
+
+		parsed, err := Parse(r5994i, tc.String())
+		assert.Nil(t, err)
+		assert.Equal(t, tc, parsed)
+	}
+
+	_, err = FromFields(R25, 51)
+	assert.NotNil(t, err)
+
+	_, err = FromFields(r50i.WithWrap(WrapError), -1)
+	assert.True(t, errors.Is(err, ErrUnderflow))
+
+	tc, err = FromFields(r50i.WithWrap(Wrap24Hour), -1)
+	assert.Nil(t, err)
+	assert.Equal(t, "23:59:59.24", tc.String())
+
+	_, err = FromFrames(r50i, math.MaxInt64).Fields()
+	assert.True(t, errors.Is(err, ErrOverflow))
+
+	_, err = FromFrames(r50i, math.MinInt64).Fields()
+	assert.True(t, errors.Is(err, ErrUnderflow))
+}
+
+func TestWithField(t *testing.T) {
+	t.Parallel()
+
+	tc := FromFrames(r50i, 90000)
+	assert.Equal(t, 1, tc.Field())
+	assert.Equal(t, "01:00:00:00", tc.String())
+
+	second, err := tc.WithField(2)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, second.Field())
+	assert.Equal(t, "01:00:00.00", second.String())
+	assert.True(t, tc.Before(second))
+	assert.True(t, second.After(tc))
+	assert.False(t, tc.Equal(second))
+
+	first, err := second.WithField(1)
+	assert.Nil(t, err)
+	assert.Equal(t, tc, first)
+
+	_, err = tc.WithField(3)
+	assert.NotNil(t, err)
+
+	_, err = FromFrames(R25, 0).WithField(2)
+	assert.NotNil(t, err)
+
+	progressive, err := FromFrames(R25, 0).WithField(1)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, progressive.Field())
+}
+
+func TestAddFields(t *testing.T) {
+	t.Parallel()
+
+	tc := FromFrames(r5994i, 0)
+
+	tests := []struct {
+		fields   int64
+		expected string
+	}{
+		{1, "00:00:00,00"},
+		{2, "00:00:00;01"},
+		{3, "00:00:00,01"},
+		{-1, "-00:00:00,00"},
+		{-2, "-00:00:00;01"},
+		{3600, "00:01:00;02"},
+	}
+
+	for _, test := range tests {
+		result, err := tc.AddFields(test.fields)
+		assert.Nil(t, err)
+		assert.Equal(t, test.expected, result.String(), test.fields)
+
+		expected, err := FromFields(r5994i, test.fields)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, result)
+	}
+
+	second, err := tc.WithField(2)
+	assert.Nil(t, err)
+	result, err := second.AddFields(1)
+	assert.Nil(t, err)
+	assert.Equal(t, "00:00:00;01", result.String())
+
+	result, err = second.Add(1)
+	assert.Nil(t, err)
+	assert.Equal(t, "00:00:00,01", result.String())
+
+	_, err = FromFrames(R25, 0).AddFields(1)
+	assert.NotNil(t, err)
+}
+
+func TestParseFields(t *testing.T) {
+	t.Parallel()
+
+	tc, err := Parse(r50i, "10:00:00.12")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, tc.Field())
+	assert.Equal(t, int64(900012), tc.Frames())
+
+	tc, err = Parse(r50i, "10:00:00:12")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, tc.Field())
+
+	tc, err = Parse(r5994i, "01:00:00,00")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, tc.Field())
+	assert.Equal(t, "01:00:00,00", tc.String())
+
+	// progressive rates ignore the separator
+	tc, err = Parse(R25, "10:00:00.12")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, tc.Field())
+	assert.Equal(t, "10:00:00:12", tc.String())
+
+	tc, err = ParseWithLayout(r50i, LayoutMinutes, "90:00.12")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, tc.Field())
+	assert.Equal(t, "01:30:00.12", tc.String())
+
+	_, err = ParseWithLayout(R25, LayoutMinutes, "90:00.12")
+	assert.NotNil(t, err)
+}
+
+func TestMarshalFields(t *testing.T) {
+	t.Parallel()
+
+	tc, err := Parse(r5994i, "01:00:00,00")
+	assert.Nil(t, err)
+
+	data, err := json.Marshal(tc)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"timecode":"01:00:00,00","rate":"60000/1001i DF"}`, string(data))
+
+	var back Timecode
+	assert.Nil(t, json.Unmarshal(data, &back))
+	assert.Equal(t, tc, back)
+
+	text, err := tc.MarshalText()
+	assert.Nil(t, err)
+	back = Timecode{}
+	assert.Nil(t, back.UnmarshalText(text))
+	assert.Equal(t, tc, back)
+
+	binary, err := tc.MarshalBinary()
+	assert.Nil(t, err)
+	back = Timecode{}
+	assert.Nil(t, back.UnmarshalBinary(binary))
+	assert.Equal(t, tc, back)
+
+	progressive, err := FromFrames(R2997DF, 0).MarshalBinary()
+	assert.Nil(t, err)
+	progressive[rateBinaryLen+8] = 1
+	assert.NotNil(t, back.UnmarshalBinary(progressive))
+}
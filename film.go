@@ -0,0 +1,282 @@
+package timecode
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	feetFramesRegExp = regexp.MustCompile(`^(-?)(\d+)\+(\d+)$`)
+)
+
+var (
+	// Gauge35mm4Perf is 35mm film with 4 perforations per frame and 16 frames per foot.
+	Gauge35mm4Perf = Gauge{name: "35mm 4-perf", perfsPerFoot: 64, perfsPerFrame: 4}
+
+	// Gauge35mm3Perf is 35mm film with 3 perforations per frame and 21 1/3 frames per foot. The frame
+	// count repeats every 3 feet as 22, 21 and 21 frames.
+	Gauge35mm3Perf = Gauge{name: "35mm 3-perf", perfsPerFoot: 64, perfsPerFrame: 3}
+
+	// Gauge16mm is 16mm film with 40 frames per foot.
+	Gauge16mm = Gauge{name: "16mm", perfsPerFoot: 40, perfsPerFrame: 1}
+
+	// Gauge65mm5Perf is 65mm film with 5 perforations per frame and 12.8 frames per foot. The frame count
+	// repeats every 5 feet as 13, 13, 13, 13 and 12 frames.
+	Gauge65mm5Perf = Gauge{name: "65mm 5-perf", perfsPerFoot: 64, perfsPerFrame: 5}
+)
+
+// Gauge describes a film gauge and the number of perforations pulled down for each frame. It's used to
+// convert between frames and feet+frames. When a foot doesn't hold a whole number of frames a frame belongs
+// to the foot its first perforation is in.
+type Gauge struct {
+	name          string
+	perfsPerFoot  int64
+	perfsPerFrame int64
+}
+
+// NewGauge returns a Gauge with the passed name, perforations per foot and perforations per frame. Both
+// perfsPerFoot and perfsPerFrame must be at least 1.
+func NewGauge(name string, perfsPerFoot, perfsPerFrame int64) (Gauge, error) {
+	if perfsPerFoot < 1 || perfsPerFrame < 1 {
+		return Gauge{}, fmt.Errorf("gauge must have at least 1 perf per foot and frame but got: %d and %d", perfsPerFoot, perfsPerFrame)
+	}
+
+	return Gauge{
+		name:          name,
+		perfsPerFoot:  perfsPerFoot,
+		perfsPerFrame: perfsPerFrame,
+	}, nil
+}
+
+// String returns the name of the Gauge.
+func (g Gauge) String() string {
+	return g.name
+}
+
+// FramesPerFoot returns the average number of frames in a foot. For example 16 for 35mm 4-perf and
+// 21.333 for 35mm 3-perf.
+func (g Gauge) FramesPerFoot() float64 {
+	return float64(g.perfsPerFoot) / float64(g.perfsPerFrame)
+}
+
+// FeetFrames returns frames as feet+frames. Negative frames result in negative feet and frames.
+func (g Gauge) FeetFrames(frames int64) FeetFrames {
+	negative := frames < 0
+
+	// split into whole cycles of feet that hold a whole number of frames to avoid overflowing perfs
+	cycleFeet := uint64(g.perfsPerFrame / gcd(g.perfsPerFoot, g.perfsPerFrame))
+	cycleFrames := cycleFeet * uint64(g.perfsPerFoot) / uint64(g.perfsPerFrame)
+
+	cycles := abs(frames) / cycleFrames
+	remaining := abs(frames) % cycleFrames
+	foot := remaining * uint64(g.perfsPerFrame) / uint64(g.perfsPerFoot)
+
+	ff := FeetFrames{
+		Feet:   int64(cycles*cycleFeet + foot),
+		Frames: int64(remaining - g.firstFrame(foot)),
+	}
+	if negative {
+		ff.Feet, ff.Frames = -ff.Feet, -ff.Frames
+	}
+
+	return ff
+}
+
+// Frames returns the number of frames in ff. An error is returned if the frames of ff aren't within the
+// foot or ff has mixed signs.
+func (g Gauge) Frames(ff FeetFrames) (int64, error) {
+	if (ff.Feet < 0 && ff.Frames > 0) || (ff.Feet > 0 && ff.Frames < 0) {
+		return 0, fmt.Errorf("feet and frames must have the same sign: %s", ff)
+	}
+
+	negative := ff.Feet < 0 || ff.Frames < 0
+	feet, frames := ff.Feet, ff.Frames
+	if negative {
+		feet, frames = -feet, -frames
+	}
+	if feet < 0 || frames < 0 {
+		return 0, fmt.Errorf("%w: %s", ErrOverflow, ff)
+	}
+
+	cycleFeet := g.perfsPerFrame / gcd(g.perfsPerFoot, g.perfsPerFrame)
+	cycleFrames := cycleFeet * g.perfsPerFoot / g.perfsPerFrame
+
+	foot := uint64(feet % cycleFeet)
+	if footFrames := int64(g.firstFrame(foot+1) - g.firstFrame(foot)); frames >= footFrames {
+		return 0, fmt.Errorf("frames must be between 0 and %d got: %d", footFrames-1, frames)
+	}
+
+	cycles := feet / cycleFeet
+	if cycles > (math.MaxInt64-cycleFrames)/cycleFrames {
+		return 0, fmt.Errorf("%w: %s", ErrOverflow, ff)
+	}
+
+	total := cycles*cycleFrames + int64(g.firstFrame(foot)) + frames
+	if negative {
+		total = -total
+	}
+
+	return total, nil
+}
+
+// firstFrame returns the first frame that starts within foot. This is the number of perfs before the foot
+// divided by the perfs per frame rounded up.
+func (g Gauge) firstFrame(foot uint64) uint64 {
+	perfs := foot * uint64(g.perfsPerFoot)
+	return (perfs + uint64(g.perfsPerFrame) - 1) / uint64(g.perfsPerFrame)
+}
+
+// FeetFrames is a film position or length counted in feet and frames such as 1234+08. Both Feet and Frames
+// are negative for a negative position.
+type FeetFrames struct {
+	Feet   int64
+	Frames int64
+}
+
+// ParseFeetFrames takes feet+frames as a string in the form feet+frames such as 1234+08 and returns
+// FeetFrames. A leading minus sign results in negative FeetFrames.
+func ParseFeetFrames(s string) (FeetFrames, error) {
+	ff := FeetFrames{}
+
+	matches := feetFramesRegExp.FindStringSubmatch(s)
+	if len(matches) != 4 {
+		return ff, fmt.Errorf("unable to parse feet+frames: %s", s)
+	}
+
+	feet, err := strconv.ParseInt(matches[2], 10, 64)
+	if err != nil {
+		return ff, fmt.Errorf("unable to parse feet: %s: %w", s, err)
+	}
+
+	frames, err := strconv.ParseInt(matches[3], 10, 64)
+	if err != nil {
+		return ff, fmt.Errorf("unable to parse frames: %s: %w", s, err)
+	}
+
+	if matches[1] == "-" {
+		feet, frames = -feet, -frames
+	}
+
+	ff.Feet = feet
+	ff.Frames = frames
+	return ff, nil
+}
+
+// String returns the feet+frames such as 1234+08.
+func (ff FeetFrames) String() string {
+	if ff.Feet < 0 || ff.Frames < 0 {
+		return fmt.Sprintf("-%d+%02d", abs(ff.Feet), abs(ff.Frames))
+	}
+	return fmt.Sprintf("%d+%02d", ff.Feet, ff.Frames)
+}
+
+// FromFeetFrames returns a Timecode based on the passed rate and the number of frames in ff for the film
+// gauge g.
+func FromFeetFrames(rate Rate, g Gauge, ff FeetFrames) (Timecode, error) {
+	tc := Timecode{
+		rate: rate,
+	}
+
+	frames, err := g.Frames(ff)
+	if err != nil {
+		return tc, err
+	}
+
+	tc.frames, err = rate.bound(frames)
+	return tc, err
+}
+
+// FeetFrames returns the frames of the Timecode as feet+frames for the film gauge g.
+func (tc Timecode) FeetFrames(g Gauge) FeetFrames {
+	return g.FeetFrames(tc.Frames())
+}
+
+// KeyCode is a film edge code such as KJ 23 1234 5678+12. The Prefix identifies the roll and is made of
+// the manufacturer, film type and roll number. The FeetFrames are the footage count from the key number
+// printed on the film and the frame offset from it.
+type KeyCode struct {
+	Prefix string
+	FeetFrames
+}
+
+// ParseKeyCode takes a key code as a string such as KJ 23 1234 5678+12 and returns a KeyCode. Everything
+// before the last space is the prefix and the last field is feet+frames.
+func ParseKeyCode(s string) (KeyCode, error) {
+	kc := KeyCode{}
+
+	s = strings.TrimSpace(s)
+	i := strings.LastIndexAny(s, " \t")
+
+	ff, err := ParseFeetFrames(s[i+1:])
+	if err != nil {
+		return kc, fmt.Errorf("unable to parse key code: %s: %w", s, err)
+	}
+
+	if i >= 0 {
+		kc.Prefix = strings.TrimSpace(s[:i])
+	}
+	kc.FeetFrames = ff
+	return kc, nil
+}
+
+// String returns the key code such as KJ 23 1234 5678+12.
+func (kc KeyCode) String() string {
+	if kc.Prefix == "" {
+		return kc.FeetFrames.String()
+	}
+	return kc.Prefix + " " + kc.FeetFrames.String()
+}
+
+// KeyCode returns the key code of the Timecode given that the film frame at sync has the key code ref.
+// The result is ref offset by the frames between sync and tc. An ErrRateMismatch error is returned if tc
+// and sync don't use the same Rate.
+func (tc Timecode) KeyCode(g Gauge, sync Timecode, ref KeyCode) (KeyCode, error) {
+	offset, err := Diff(tc, sync)
+	if err != nil {
+		return KeyCode{}, err
+	}
+
+	refFrames, err := g.Frames(ref.FeetFrames)
+	if err != nil {
+		return KeyCode{}, err
+	}
+
+	frames, err := addFrames(refFrames, offset)
+	if err != nil {
+		return KeyCode{}, err
+	}
+
+	return KeyCode{
+		Prefix:     ref.Prefix,
+		FeetFrames: g.FeetFrames(frames),
+	}, nil
+}
+
+// FromKeyCode returns the Timecode of the film frame with the key code kc given that the film frame at
+// sync has the key code ref. The prefixes of kc and ref must match since key codes from different rolls
+// can't be compared.
+func FromKeyCode(g Gauge, sync Timecode, ref, kc KeyCode) (Timecode, error) {
+	if kc.Prefix != ref.Prefix {
+		return Timecode{}, fmt.Errorf("key code %s is not on the same roll as %s", kc, ref)
+	}
+
+	frames, err := g.Frames(kc.FeetFrames)
+	if err != nil {
+		return Timecode{}, err
+	}
+
+	refFrames, err := g.Frames(ref.FeetFrames)
+	if err != nil {
+		return Timecode{}, err
+	}
+
+	offset, err := subFrames(frames, refFrames)
+	if err != nil {
+		return Timecode{}, err
+	}
+
+	return sync.Add(offset)
+}
@@ -0,0 +1,181 @@
+package timecode
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGaugeFeetFrames(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		gauge    Gauge
+		frames   int64
+		expected string
+	}{
+		{Gauge35mm4Perf, 0, "0+00"},
+		{Gauge35mm4Perf, 15, "0+15"},
+		{Gauge35mm4Perf, 16, "1+00"},
+		{Gauge35mm4Perf, 129903, "8118+15"},
+		{Gauge35mm4Perf, -17, "-1+01"},
+		{Gauge35mm3Perf, 21, "0+21"},
+		{Gauge35mm3Perf, 22, "1+00"},
+		{Gauge35mm3Perf, 42, "1+20"},
+		{Gauge35mm3Perf, 43, "2+00"},
+		{Gauge35mm3Perf, 63, "2+20"},
+		{Gauge35mm3Perf, 64, "3+00"},
+		{Gauge35mm3Perf, 86, "4+00"},
+		{Gauge16mm, 39, "0+39"},
+		{Gauge16mm, 40, "1+00"},
+		{Gauge16mm, 1440, "36+00"},
+		{Gauge65mm5Perf, 12, "0+12"},
+		{Gauge65mm5Perf, 13, "1+00"},
+		{Gauge65mm5Perf, 52, "4+00"},
+		{Gauge65mm5Perf, 63, "4+11"},
+		{Gauge65mm5Perf, 64, "5+00"},
+	}
+
+	for _, test := range tests {
+		ff := test.gauge.FeetFrames(test.frames)
+		assert.Equal(t, test.expected, ff.String(), test.gauge.String())
+
+		frames, err := test.gauge.Frames(ff)
+		assert.Nil(t, err)
+		assert.Equal(t, test.frames, frames, test.expected)
+	}
+
+	// every frame is counted once
+	for _, gauge := range []Gauge{Gauge35mm4Perf, Gauge35mm3Perf, Gauge16mm, Gauge65mm5Perf} {
+		prev := gauge.FeetFrames(-1000)
+		for frames := int64(-999); frames < 1000; frames++ {
+			ff := gauge.FeetFrames(frames)
+			assert.True(t, ff != prev)
+			back, err := gauge.Frames(ff)
+			assert.Nil(t, err)
+			assert.Equal(t, frames, back)
+			prev = ff
+		}
+	}
+
+	_, err := Gauge35mm3Perf.Frames(FeetFrames{Feet: 1, Frames: 21})
+	assert.NotNil(t, err)
+
+	_, err = Gauge35mm3Perf.Frames(FeetFrames{Feet: 0, Frames: 21})
+	assert.Nil(t, err)
+
+	_, err = Gauge35mm4Perf.Frames(FeetFrames{Feet: 1, Frames: 16})
+	assert.NotNil(t, err)
+
+	_, err = Gauge35mm4Perf.Frames(FeetFrames{Feet: 1, Frames: -1})
+	assert.NotNil(t, err)
+
+	_, err = Gauge35mm4Perf.Frames(FeetFrames{Feet: 1 << 62, Frames: 0})
+	assert.True(t, errors.Is(err, ErrOverflow))
+
+	assert.Equal(t, 16.0, Gauge35mm4Perf.FramesPerFoot())
+	assert.Equal(t, 12.8, Gauge65mm5Perf.FramesPerFoot())
+}
+
+func TestNewGauge(t *testing.T) {
+	t.Parallel()
+
+	gauge, err := NewGauge("35mm 2-perf", 64, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, "35mm 2-perf", gauge.String())
+	assert.Equal(t, "1+00", gauge.FeetFrames(32).String())
+
+	_, err = NewGauge("bad", 0, 4)
+	assert.NotNil(t, err)
+}
+
+func TestParseFeetFrames(t *testing.T) {
+	t.Parallel()
+
+	ff, err := ParseFeetFrames("1234+08")
+	assert.Nil(t, err)
+	assert.Equal(t, FeetFrames{Feet: 1234, Frames: 8}, ff)
+	assert.Equal(t, "1234+08", ff.String())
+
+	ff, err = ParseFeetFrames("-12+03")
+	assert.Nil(t, err)
+	assert.Equal(t, FeetFrames{Feet: -12, Frames: -3}, ff)
+	assert.Equal(t, "-12+03", ff.String())
+
+	for _, s := range []string{"", "1234", "+08", "1234+", "12-08", "a+b", "99999999999999999999+00"} {
+		_, err := ParseFeetFrames(s)
+		assert.NotNil(t, err, s)
+	}
+}
+
+func TestTimecodeFeetFrames(t *testing.T) {
+	t.Parallel()
+
+	tc, err := Parse(R24, "01:30:12:15")
+	assert.Nil(t, err)
+	assert.Equal(t, "8118+15", tc.FeetFrames(Gauge35mm4Perf).String())
+	assert.Equal(t, "3247+23", tc.FeetFrames(Gauge16mm).String())
+	assert.Equal(t, tc.Format(LayoutFeetFrames), tc.FeetFrames(Gauge35mm4Perf).String())
+
+	back, err := FromFeetFrames(R24, Gauge16mm, tc.FeetFrames(Gauge16mm))
+	assert.Nil(t, err)
+	assert.Equal(t, tc, back)
+
+	ff, err := ParseFeetFrames("90+00")
+	assert.Nil(t, err)
+	tc, err = FromFeetFrames(R24, Gauge35mm4Perf, ff)
+	assert.Nil(t, err)
+	assert.Equal(t, "00:01:00:00", tc.String())
+
+	tc, err = FromFeetFrames(R24, Gauge35mm3Perf, ff)
+	assert.Nil(t, err)
+	assert.Equal(t, "00:01:20:00", tc.String())
+
+	_, err = FromFeetFrames(R24, Gauge35mm4Perf, FeetFrames{Feet: 1, Frames: 20})
+	assert.NotNil(t, err)
+}
+
+func TestKeyCode(t *testing.T) {
+	t.Parallel()
+
+	ref, err := ParseKeyCode("KJ 23 1234 5678+12")
+	assert.Nil(t, err)
+	assert.Equal(t, "KJ 23 1234", ref.Prefix)
+	assert.Equal(t, FeetFrames{Feet: 5678, Frames: 12}, ref.FeetFrames)
+	assert.Equal(t, "KJ 23 1234 5678+12", ref.String())
+
+	sync, err := Parse(R24, "01:00:00:00")
+	assert.Nil(t, err)
+
+	tc, err := Parse(R24, "01:00:01:00")
+	assert.Nil(t, err)
+
+	kc, err := tc.KeyCode(Gauge35mm4Perf, sync, ref)
+	assert.Nil(t, err)
+	assert.Equal(t, "KJ 23 1234 5680+04", kc.String())
+
+	back, err := FromKeyCode(Gauge35mm4Perf, sync, ref, kc)
+	assert.Nil(t, err)
+	assert.Equal(t, tc, back)
+
+	tc, err = Parse(R24, "00:59:59:00")
+	assert.Nil(t, err)
+	kc, err = tc.KeyCode(Gauge35mm4Perf, sync, ref)
+	assert.Nil(t, err)
+	assert.Equal(t, "KJ 23 1234 5677+04", kc.String())
+
+	_, err = FromKeyCode(Gauge35mm4Perf, sync, ref, KeyCode{Prefix: "KJ 23 9999", FeetFrames: kc.FeetFrames})
+	assert.NotNil(t, err)
+
+	_, err = tc.KeyCode(Gauge35mm4Perf, FromFrames(R25, 0), ref)
+	assert.True(t, errors.Is(err, ErrRateMismatch))
+
+	kc, err = ParseKeyCode("5678+12")
+	assert.Nil(t, err)
+	assert.Equal(t, "", kc.Prefix)
+	assert.Equal(t, "5678+12", kc.String())
+
+	_, err = ParseKeyCode("KJ 23 1234 5678")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,323 @@
+package timecode
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// Interval is a Range with a value attached such as a marker or QC event.
+type Interval struct {
+	Range Range
+	Value interface{}
+}
+
+// IntervalTree is an index of Intervals for finding the ones that hold a Timecode or overlap a Range. It's
+// an augmented AVL tree ordered by in point where each node holds the latest out point below it, so
+// Insert and Delete take O(log n) time and queries take O(log n + k) time where k is the number of
+// Intervals found. Empty Ranges may be inserted but are never found by a query. The zero value isn't
+// usable, use NewIntervalTree. An IntervalTree isn't safe for concurrent use.
+type IntervalTree struct {
+	rate   Rate
+	root   *intervalNode
+	size   int
+	nextID uint64
+}
+
+// intervalNode is a node of an IntervalTree. The id breaks ties between Intervals with the same Range so
+// every node has a unique key.
+type intervalNode struct {
+	interval Interval
+	id       uint64
+	in, out  int64
+	max      int64
+	height   int
+	left     *intervalNode
+	right    *intervalNode
+}
+
+// NewIntervalTree returns an empty IntervalTree for Intervals using rate.
+func NewIntervalTree(rate Rate) *IntervalTree {
+	return &IntervalTree{
+		rate: rate,
+	}
+}
+
+// Len returns the number of Intervals in the IntervalTree.
+func (t *IntervalTree) Len() int {
+	return t.size
+}
+
+// Insert adds an Interval for r holding value. An ErrRateMismatch error is returned if r doesn't use the
+// Rate of the IntervalTree.
+func (t *IntervalTree) Insert(r Range, value interface{}) error {
+	if err := t.checkRate(r.in.rate); err != nil {
+		return err
+	}
+
+	node := &intervalNode{
+		interval: Interval{Range: r, Value: value},
+		id:       t.nextID,
+		in:       r.in.frames,
+		out:      r.out.frames,
+		max:      r.out.frames,
+		height:   1,
+	}
+	t.nextID++
+	t.size++
+
+	t.root = t.root.insert(node)
+	return nil
+}
+
+// Delete removes one Interval with the Range r and a Value equal to value and returns true if one was
+// found. Values are compared with reflect.DeepEqual so Values such as slices and maps may be used.
+func (t *IntervalTree) Delete(r Range, value interface{}) bool {
+	if r.in.rate != t.rate {
+		return false
+	}
+
+	node := t.root.find(r.in.frames, r.out.frames, value)
+	if node == nil {
+		return false
+	}
+
+	t.root = t.root.delete(node.in, node.out, node.id)
+	t.size--
+	return true
+}
+
+// Stab returns the Intervals that hold tc ordered by in point. An ErrRateMismatch error is returned if tc
+// doesn't use the Rate of the IntervalTree.
+func (t *IntervalTree) Stab(tc Timecode) ([]Interval, error) {
+	if err := t.checkRate(tc.rate); err != nil {
+		return nil, err
+	}
+
+	// the out point of a Range is at most math.MaxInt64 so no Range holds the last frame
+	if tc.frames == math.MaxInt64 {
+		return nil, nil
+	}
+
+	var intervals []Interval
+	t.root.overlapping(tc.frames, tc.frames+1, &intervals)
+	return intervals, nil
+}
+
+// Overlapping returns the Intervals that have at least one frame in common with r ordered by in point.
+// An ErrRateMismatch error is returned if r doesn't use the Rate of the IntervalTree.
+func (t *IntervalTree) Overlapping(r Range) ([]Interval, error) {
+	if err := t.checkRate(r.in.rate); err != nil {
+		return nil, err
+	}
+
+	var intervals []Interval
+	if !r.Empty() {
+		t.root.overlapping(r.in.frames, r.out.frames, &intervals)
+	}
+	return intervals, nil
+}
+
+// Intervals returns every Interval in the IntervalTree ordered by in point.
+func (t *IntervalTree) Intervals() []Interval {
+	intervals := make([]Interval, 0, t.size)
+	t.root.walk(func(n *intervalNode) {
+		intervals = append(intervals, n.interval)
+	})
+	return intervals
+}
+
+// checkRate returns an ErrRateMismatch error if rate isn't the Rate of the IntervalTree.
+func (t *IntervalTree) checkRate(rate Rate) error {
+	if rate != t.rate {
+		return fmt.Errorf("%w: %s and %s", ErrRateMismatch, t.rate, rate)
+	}
+	return nil
+}
+
+// less returns true if n is ordered before the node with the key in, out and id.
+func (n *intervalNode) less(in, out int64, id uint64) bool {
+	if n.in != in {
+		return n.in < in
+	}
+	if n.out != out {
+		return n.out < out
+	}
+	return n.id < id
+}
+
+// overlapping appends the Intervals below n that overlap the frames [in, out) in order. Subtrees where
+// every Interval ends at or before in are skipped using max and right subtrees are skipped once the in point
+// of a node is at or after out.
+func (n *intervalNode) overlapping(in, out int64, intervals *[]Interval) {
+	if n == nil || n.max <= in {
+		return
+	}
+
+	n.left.overlapping(in, out, intervals)
+
+	if n.in >= out {
+		return
+	}
+
+	if n.in < n.out && n.out > in {
+		*intervals = append(*intervals, n.interval)
+	}
+
+	n.right.overlapping(in, out, intervals)
+}
+
+// find returns the first node below n with the Range in, out and a Value equal to value.
+func (n *intervalNode) find(in, out int64, value interface{}) *intervalNode {
+	if n == nil {
+		return nil
+	}
+
+	// nodes with the same range can be on either side of n since they're ordered by id
+	if n.in > in || (n.in == in && n.out >= out) {
+		if found := n.left.find(in, out, value); found != nil {
+			return found
+		}
+	}
+
+	if n.in == in && n.out == out && reflect.DeepEqual(n.interval.Value, value) {
+		return n
+	}
+
+	if n.in < in || (n.in == in && n.out <= out) {
+		return n.right.find(in, out, value)
+	}
+
+	return nil
+}
+
+// walk calls fn for each node below n in order.
+func (n *intervalNode) walk(fn func(*intervalNode)) {
+	if n == nil {
+		return
+	}
+
+	n.left.walk(fn)
+	fn(n)
+	n.right.walk(fn)
+}
+
+// insert adds node below n and returns the new root of the subtree.
+func (n *intervalNode) insert(node *intervalNode) *intervalNode {
+	if n == nil {
+		return node
+	}
+
+	if node.less(n.in, n.out, n.id) {
+		n.left = n.left.insert(node)
+	} else {
+		n.right = n.right.insert(node)
+	}
+
+	return n.rebalance()
+}
+
+// delete removes the node with the key in, out and id below n and returns the new root of the subtree.
+func (n *intervalNode) delete(in, out int64, id uint64) *intervalNode {
+	if n == nil {
+		return nil
+	}
+
+	switch {
+	case n.in == in && n.out == out && n.id == id:
+		if n.left == nil {
+			return n.right
+		}
+		if n.right == nil {
+			return n.left
+		}
+
+		// replace n with the first node of the right subtree
+		successor := n.right
+		for successor.left != nil {
+			successor = successor.left
+		}
+		successor.right = n.right.delete(successor.in, successor.out, successor.id)
+		successor.left = n.left
+		return successor.rebalance()
+	case n.less(in, out, id):
+		n.right = n.right.delete(in, out, id)
+	default:
+		n.left = n.left.delete(in, out, id)
+	}
+
+	return n.rebalance()
+}
+
+// rebalance updates the height and max of n and rotates the subtree if it's out of balance. The new root
+// of the subtree is returned.
+func (n *intervalNode) rebalance() *intervalNode {
+	n.update()
+
+	switch balance := n.left.getHeight() - n.right.getHeight(); {
+	case balance > 1:
+		if n.left.left.getHeight() < n.left.right.getHeight() {
+			n.left = n.left.rotateLeft()
+		}
+		return n.rotateRight()
+	case balance < -1:
+		if n.right.right.getHeight() < n.right.left.getHeight() {
+			n.right = n.right.rotateRight()
+		}
+		return n.rotateLeft()
+	}
+
+	return n
+}
+
+// rotateLeft makes the right child of n the root of the subtree and returns it.
+func (n *intervalNode) rotateLeft() *intervalNode {
+	root := n.right
+	n.right = root.left
+	root.left = n
+
+	n.update()
+	root.update()
+	return root
+}
+
+// rotateRight makes the left child of n the root of the subtree and returns it.
+func (n *intervalNode) rotateRight() *intervalNode {
+	root := n.left
+	n.left = root.right
+	root.right = n
+
+	n.update()
+	root.update()
+	return root
+}
+
+// update sets the height and max of n from its children.
+func (n *intervalNode) update() {
+	n.height = 1
+	n.max = n.out
+
+	if n.left != nil {
+		n.height = n.left.height + 1
+		if n.left.max > n.max {
+			n.max = n.left.max
+		}
+	}
+
+	if n.right != nil {
+		if n.right.height+1 > n.height {
+			n.height = n.right.height + 1
+		}
+		if n.right.max > n.max {
+			n.max = n.right.max
+		}
+	}
+}
+
+// getHeight returns the height of n or 0 for a nil node.
+func (n *intervalNode) getHeight() int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
@@ -0,0 +1,238 @@
+package timecode
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// linearOverlapping returns the intervals that overlap r by checking every interval. It's the reference the
+// IntervalTree is tested and benchmarked against.
+func linearOverlapping(intervals []Interval, r Range) []Interval {
+	var found []Interval
+	for _, interval := range intervals {
+		if interval.Range.Overlaps(r) {
+			found = append(found, interval)
+		}
+	}
+	return found
+}
+
+func randomIntervals(rng *rand.Rand, n int, span, length int64) []Interval {
+	intervals := make([]Interval, n)
+	for i := range intervals {
+		in := rng.Int63n(span)
+		intervals[i] = Interval{Range: testRange(in, in+rng.Int63n(length)), Value: i}
+	}
+	return intervals
+}
+
+func sortIntervals(intervals []Interval) []Interval {
+	sorted := append([]Interval(nil), intervals...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Value.(int) < sorted[j].Value.(int)
+	})
+	return sorted
+}
+
+func assertBalanced(t *testing.T, n *intervalNode) int {
+	if n == nil {
+		return 0
+	}
+
+	left, right := assertBalanced(t, n.left), assertBalanced(t, n.right)
+	assert.True(t, left-right <= 1 && right-left <= 1)
+	assert.Equal(t, n.max, maxOut(n))
+
+	if left > right {
+		return left + 1
+	}
+	return right + 1
+}
+
+func maxOut(n *intervalNode) int64 {
+	max := n.out
+	n.walk(func(child *intervalNode) {
+		if child.out > max {
+			max = child.out
+		}
+	})
+	return max
+}
+
+func TestIntervalTree(t *testing.T) {
+	t.Parallel()
+
+	tree := NewIntervalTree(R25)
+	assert.Nil(t, tree.Insert(testRange(0, 10), "a"))
+	assert.Nil(t, tree.Insert(testRange(5, 15), "b"))
+	assert.Nil(t, tree.Insert(testRange(20, 30), "c"))
+	assert.Nil(t, tree.Insert(testRange(5, 15), "d"))
+	assert.Nil(t, tree.Insert(testRange(8, 8), "empty"))
+	assert.Equal(t, 5, tree.Len())
+
+	found, err := tree.Stab(FromFrames(R25, 8))
+	assert.Nil(t, err)
+	assert.Equal(t, []Interval{
+		{Range: testRange(0, 10), Value: "a"},
+		{Range: testRange(5, 15), Value: "b"},
+		{Range: testRange(5, 15), Value: "d"},
+	}, found)
+
+	found, err = tree.Stab(FromFrames(R25, 15))
+	assert.Nil(t, err)
+	assert.Len(t, found, 0)
+
+	found, err = tree.Overlapping(testRange(12, 21))
+	assert.Nil(t, err)
+	assert.Equal(t, []Interval{
+		{Range: testRange(5, 15), Value: "b"},
+		{Range: testRange(5, 15), Value: "d"},
+		{Range: testRange(20, 30), Value: "c"},
+	}, found)
+
+	found, err = tree.Overlapping(testRange(12, 12))
+	assert.Nil(t, err)
+	assert.Len(t, found, 0)
+
+	assert.True(t, tree.Delete(testRange(5, 15), "d"))
+	assert.False(t, tree.Delete(testRange(5, 15), "d"))
+	assert.False(t, tree.Delete(testRange(5, 16), "b"))
+	assert.Equal(t, 4, tree.Len())
+
+	found, err = tree.Stab(FromFrames(R25, 8))
+	assert.Nil(t, err)
+	assert.Len(t, found, 2)
+	assert.Len(t, tree.Intervals(), 4)
+
+	_, err = tree.Stab(FromFrames(R24, 8))
+	assert.True(t, errors.Is(err, ErrRateMismatch))
+
+	err = tree.Insert(Range{in: FromFrames(R24, 0), out: FromFrames(R24, 10)}, "e")
+	assert.True(t, errors.Is(err, ErrRateMismatch))
+
+	// values that aren't comparable with == such as slices and maps can be deleted
+	assert.Nil(t, tree.Insert(testRange(30, 40), []string{"dropout", "audio"}))
+	assert.Nil(t, tree.Insert(testRange(30, 40), map[string]int{"level": 3}))
+	assert.False(t, tree.Delete(testRange(30, 40), []string{"dropout"}))
+	assert.True(t, tree.Delete(testRange(30, 40), []string{"dropout", "audio"}))
+	assert.True(t, tree.Delete(testRange(30, 40), map[string]int{"level": 3}))
+	assert.Equal(t, 4, tree.Len())
+
+	// the last frame can't be held by any Range
+	last := Range{in: FromFrames(R25, math.MaxInt64-1), out: FromFrames(R25, math.MaxInt64)}
+	assert.Nil(t, tree.Insert(last, "last"))
+	found, err = tree.Stab(FromFrames(R25, math.MaxInt64-1))
+	assert.Nil(t, err)
+	assert.Len(t, found, 1)
+	found, err = tree.Stab(FromFrames(R25, math.MaxInt64))
+	assert.Nil(t, err)
+	assert.Len(t, found, 0)
+}
+
+func TestIntervalTreeMatchesLinear(t *testing.T) {
+	t.Parallel()
+
+	rng := rand.New(rand.NewSource(1))
+	intervals := randomIntervals(rng, 2000, 100000, 1000)
+
+	tree := NewIntervalTree(R25)
+	for _, interval := range intervals {
+		assert.Nil(t, tree.Insert(interval.Range, interval.Value))
+	}
+	assertBalanced(t, tree.root)
+
+	// delete every third interval
+	var kept []Interval
+	for i, interval := range intervals {
+		if i%3 == 0 {
+			assert.True(t, tree.Delete(interval.Range, interval.Value))
+			continue
+		}
+		kept = append(kept, interval)
+	}
+	assert.Equal(t, len(kept), tree.Len())
+	assertBalanced(t, tree.root)
+
+	for i := 0; i < 200; i++ {
+		in := rng.Int63n(110000) - 5000
+		r := testRange(in, in+rng.Int63n(2000))
+
+		found, err := tree.Overlapping(r)
+		assert.Nil(t, err)
+		assert.Equal(t, sortIntervals(linearOverlapping(kept, r)), sortIntervals(found))
+
+		found, err = tree.Stab(r.In())
+		assert.Nil(t, err)
+		assert.Equal(t, sortIntervals(linearOverlapping(kept, testRange(in, in+1))), sortIntervals(found))
+	}
+}
+
+func BenchmarkIntervalTreeStab(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	intervals := randomIntervals(rng, 200000, 2160000, 250)
+
+	tree := NewIntervalTree(R25)
+	for _, interval := range intervals {
+		_ = tree.Insert(interval.Range, interval.Value)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = tree.Stab(FromFrames(R25, rng.Int63n(2160000)))
+	}
+}
+
+func BenchmarkLinearStab(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	intervals := randomIntervals(rng, 200000, 2160000, 250)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		in := rng.Int63n(2160000)
+		linearOverlapping(intervals, testRange(in, in+1))
+	}
+}
+
+func BenchmarkIntervalTreeOverlapping(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	intervals := randomIntervals(rng, 200000, 2160000, 250)
+
+	tree := NewIntervalTree(R25)
+	for _, interval := range intervals {
+		_ = tree.Insert(interval.Range, interval.Value)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		in := rng.Int63n(2160000)
+		_, _ = tree.Overlapping(testRange(in, in+250))
+	}
+}
+
+func BenchmarkLinearOverlapping(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	intervals := randomIntervals(rng, 200000, 2160000, 250)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		in := rng.Int63n(2160000)
+		linearOverlapping(intervals, testRange(in, in+250))
+	}
+}
+
+func BenchmarkIntervalTreeInsert(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	intervals := randomIntervals(rng, b.N, 2160000, 250)
+
+	tree := NewIntervalTree(R25)
+
+	b.ResetTimer()
+	for _, interval := range intervals {
+		_ = tree.Insert(interval.Range, interval.Value)
+	}
+}
@@ -0,0 +1,491 @@
+package timecode
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Layouts for use with Timecode.Format. A layout is made of literal text and verbs that start with %.
+// The verbs are:
+//
+//	%H  hours
+//	%M  minutes
+//	%S  seconds
+//	%F  frames
+//	%U  sub-frames for rates with sub-frames
+//	%L  milliseconds
+//	%K  feet of 35mm 4-perf film (16 frames per foot)
+//	%s  real elapsed seconds with up to three decimal places such as 5412.5
+//	%;  the frame separator, ; for drop frame rates and : otherwise, or the alternate frame separator
+//	    for the second field of an interlaced rate
+//	%.  the alternate frame separator, , for drop frame rates and . otherwise
+//	%%  a literal %
+//
+// A number between % and the verb sets the minimum number of digits, for example %3H pads hours to
+// three digits. By default hours, minutes, seconds and frames are padded to two digits, milliseconds
+// to three and feet aren't padded. The largest unit in a layout carries the units missing above it so
+// %M:%S%;%F formats 01:30:12:15 as 90:12:15 and %1F formats it as the total number of frames. When %K
+// is used %F is the frame within the foot. Layouts using %L describe real elapsed time so %H, %M and
+// %S are taken from Timecode.Duration rather than the label. Negative timecodes are prefixed with -.
+//
+// The same layouts are used by ParseWithLayout. When parsing, a verb matches any number of digits unless it's
+// immediately followed by another verb in which case it matches exactly its width. %; matches : or ; and %.
+// matches . or , regardless of the drop frame encoding. For interlaced rates %; also matches . or , which
+// marks the second field. Layouts using %L or %s are rounded to the nearest frame with times halfway between
+// two frames rounded away from zero.
+const (
+	// LayoutSMPTE is the SMPTE label such as 01:00:00:00 or 01:00:00;00 for drop frame.
+	LayoutSMPTE = "%H:%M:%S%;%F"
+
+	// LayoutSMPTESubFrames is the SMPTE label followed by the sub-frame such as 01:00:00:00.45. It's used by
+	// Timecode.String for rates with sub-frames.
+	LayoutSMPTESubFrames = "%H:%M:%S%;%F.%U"
+
+	// LayoutSMPTEDot is the SMPTE label using the alternate frame separator such as 01:00:00.00 or
+	// 01:00:00,00 for drop frame.
+	LayoutSMPTEDot = "%H:%M:%S%.%F"
+
+	// LayoutFFmpeg is real elapsed time with milliseconds as used by ffmpeg and ffprobe such as 01:00:03.600.
+	LayoutFFmpeg = "%H:%M:%S.%L"
+
+	// LayoutSRT is real elapsed time with milliseconds as used by SRT subtitles such as 01:00:03,600.
+	LayoutSRT = "%H:%M:%S,%L"
+
+	// LayoutFrames is the total number of frames such as 107892.
+	LayoutFrames = "%1F"
+
+	// LayoutFeetFrames is feet and frames of 35mm 4-perf film such as 6743+04.
+	LayoutFeetFrames = "%K+%F"
+
+	// LayoutMinutes is the SMPTE label without hours such as 90:12:15 for 01:30:12:15.
+	LayoutMinutes = "%M:%S%;%F"
+
+	// LayoutSeconds is real elapsed seconds followed by s such as 5412.5s.
+	LayoutSeconds = "%ss"
+)
+
+var (
+	// anyLayouts are the layouts tried by ParseAny. Each is only tried when the string has exactly its
+	// shape. An empty layout is a SMPTE label handled by Parse.
+	anyLayouts = []struct {
+		re     *regexp.Regexp
+		layout string
+	}{
+		{regexp.MustCompile(`^-?\d+:\d\d:\d\d\.\d{3}$`), LayoutFFmpeg},
+		{regexp.MustCompile(`^-?\d+:\d\d:\d\d,\d{3}$`), LayoutSRT},
+		{timecodeRegExp, ""},
+		{regexp.MustCompile(`^-?\d+:\d\d[:;]\d+$`), LayoutMinutes},
+		{regexp.MustCompile(`^-?\d+(\.\d+)?s$`), LayoutSeconds},
+		{regexp.MustCompile(`^-?\d+$`), LayoutFrames},
+	}
+)
+
+const framesPerFoot35mm = 16
+
+// layoutItem is either a verb with a width or literal text.
+type layoutItem struct {
+	verb    byte
+	width   int
+	literal string
+}
+
+// Format returns the Timecode formatted according to layout. See LayoutSMPTE and the other Layout
+// constants for a description of layouts.
+func (tc Timecode) Format(layout string) string {
+	items := parseLayout(layout)
+
+	var has [256]bool
+	for _, item := range items {
+		has[item.verb] = true
+	}
+
+	// total is the number of whole seconds and fraction is the frames or milliseconds left over
+	var total, fraction, milli uint64
+	if has['L'] {
+		d := tc.Duration()
+		if d < 0 {
+			d = -d
+		}
+		total = uint64(d / time.Second)
+		milli = uint64(d%time.Second) / uint64(time.Millisecond)
+		fraction = milli * uint64(tc.rate.timeBase) / 1000
+	} else {
+		hour, minute, second, frame := tc.parts()
+		total = (hour*60+minute)*60 + second
+		fraction = frame
+	}
+
+	// carry the units that are missing from the layout into the largest unit
+	var hour, minute, second, frame, feet uint64
+	if has['H'] {
+		hour = total / 3600
+		total %= 3600
+	}
+	if has['M'] {
+		minute = total / 60
+		total %= 60
+	}
+	if has['S'] {
+		second = total
+		total = 0
+	}
+	if has['F'] {
+		if has['H'] || has['M'] || has['S'] {
+			frame = total*uint64(tc.rate.timeBase) + fraction
+		} else {
+			frame = tc.absFrames()
+		}
+	}
+	if has['K'] {
+		feet = tc.absFrames() / framesPerFoot35mm
+		frame = tc.absFrames() % framesPerFoot35mm
+	}
+
+	sb := strings.Builder{}
+	if tc.Negative() {
+		sb.WriteByte('-')
+	}
+
+	for _, item := range items {
+		switch item.verb {
+		case 0:
+			sb.WriteString(item.literal)
+		case 'H':
+			writePadded(&sb, hour, item.width)
+		case 'M':
+			writePadded(&sb, minute, item.width)
+		case 'S':
+			writePadded(&sb, second, item.width)
+		case 'F':
+			writePadded(&sb, frame, item.width)
+		case 'U':
+			writePadded(&sb, tc.SubFrame(), item.width)
+		case 'L':
+			writePadded(&sb, milli, item.width)
+		case 'K':
+			writePadded(&sb, feet, item.width)
+		case 's':
+			d := tc.Duration()
+			if d < 0 {
+				d = -d
+			}
+			writePadded(&sb, uint64(d/time.Second), item.width)
+			if milli := uint64(d%time.Second) / uint64(time.Millisecond); milli > 0 {
+				sb.WriteByte('.')
+				sb.WriteString(strings.TrimRight(fmt.Sprintf("%03d", milli), "0"))
+			}
+		case ';':
+			switch {
+			case tc.secondField && tc.rate.dropFrame:
+				sb.WriteByte(',')
+			case tc.secondField:
+				sb.WriteByte('.')
+			case tc.rate.dropFrame:
+				sb.WriteByte(';')
+			default:
+				sb.WriteByte(':')
+			}
+		case '.':
+			if tc.rate.dropFrame {
+				sb.WriteByte(',')
+			} else {
+				sb.WriteByte('.')
+			}
+		}
+	}
+
+	return sb.String()
+}
+
+// ParseAny takes rate and a timecode in any of the following forms and returns a Timecode. The forms are
+// recognised by their exact shape rather than by guessing at separators.
+//
+//	01:30:12.500  real elapsed time with milliseconds, LayoutFFmpeg
+//	01:30:12,500  real elapsed time with milliseconds, LayoutSRT
+//	01:30:12:15   a SMPTE label accepted by Parse
+//	90:12:15      a SMPTE label without hours, LayoutMinutes
+//	5412.5s       real elapsed seconds, LayoutSeconds
+//	162375        a number of frames, LayoutFrames
+//
+// The forms are tried in that order so exactly three digits after a final . or , are always milliseconds
+// while one or two digits are SMPTE frames. Any of the forms may start with a minus sign. Real elapsed times
+// are rounded to the nearest frame with times halfway between two frames rounded away from zero.
+func ParseAny(rate Rate, s string, opts ...ParseOption) (Timecode, error) {
+	for _, form := range anyLayouts {
+		if !form.re.MatchString(s) {
+			continue
+		}
+		if form.layout == "" {
+			return Parse(rate, s, opts...)
+		}
+		return ParseWithLayout(rate, form.layout, s, opts...)
+	}
+
+	return Timecode{rate: rate}, fmt.Errorf("unable to parse timecode: %s", s)
+}
+
+// ParseWithLayout takes rate, a layout and a timecode formatted according to the layout and returns a
+// Timecode. See LayoutSMPTE for a description of layouts. The string may start with a minus sign.
+// Minutes and seconds must be between 0 and 59 and frames must be less than the frame rate unless a
+// larger unit is missing from the layout. Drop frame labels are handled the same as Parse.
+func ParseWithLayout(rate Rate, layout, s string, opts ...ParseOption) (Timecode, error) {
+	tc := Timecode{
+		rate: rate,
+	}
+
+	options := parseOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	items := parseLayout(layout)
+	input := s
+	negative := strings.HasPrefix(input, "-")
+	input = strings.TrimPrefix(input, "-")
+
+	var has [256]bool
+	var values [256]uint64
+	fraction := new(big.Rat)
+
+	for i, item := range items {
+		has[item.verb] = true
+
+		switch item.verb {
+		case 0:
+			if !strings.HasPrefix(input, item.literal) {
+				return tc, fmt.Errorf("unable to parse timecode: %s: expected %q", s, item.literal)
+			}
+			input = input[len(item.literal):]
+			continue
+		case ';', '.':
+			seps := ":;"
+			if item.verb == '.' {
+				seps = ".,"
+			} else if rate.scan == ScanModeInterlaced {
+				seps = ":;.,"
+			}
+			if input == "" || !strings.ContainsRune(seps, rune(input[0])) {
+				return tc, fmt.Errorf("unable to parse timecode: %s: expected one of %q", s, seps)
+			}
+			if item.verb == ';' && (input[0] == '.' || input[0] == ',') {
+				tc.secondField = true
+			}
+			input = input[1:]
+			continue
+		}
+
+		// a verb followed directly by another number has a fixed width
+		n := 0
+		for n < len(input) && input[n] >= '0' && input[n] <= '9' {
+			n++
+		}
+		if i+1 < len(items) && isNumberVerb(items[i+1].verb) && n > item.width {
+			n = item.width
+		}
+		if n == 0 {
+			return tc, fmt.Errorf("unable to parse timecode: %s: expected digits for %%%c", s, item.verb)
+		}
+		digits := input[:n]
+		input = input[n:]
+
+		switch item.verb {
+		case 'L':
+			// milliseconds are a decimal fraction so .5 is 500ms
+			fraction.SetFrac(bigInt(digits), new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil))
+		case 's':
+			if strings.HasPrefix(input, ".") {
+				n = 1
+				for n < len(input) && input[n] >= '0' && input[n] <= '9' {
+					n++
+				}
+				digits += input[:n]
+				input = input[n:]
+			}
+			fraction.SetString(digits)
+		default:
+			v, err := strconv.ParseUint(digits, 10, 64)
+			if err != nil {
+				return tc, fmt.Errorf("unable to parse timecode: %s: %w", s, err)
+			}
+			values[item.verb] = v
+		}
+	}
+
+	if input != "" {
+		return tc, fmt.Errorf("unable to parse timecode: %s: unexpected %q", s, input)
+	}
+
+	frames, err := layoutFrames(rate, has, values, fraction, options)
+	if err != nil {
+		return tc, fmt.Errorf("%w: %s", err, s)
+	}
+
+	var subFrame int64
+	if has['U'] {
+		subFrame, err = parseSubFrame(rate, strconv.FormatUint(values['U'], 10))
+		if err != nil {
+			return tc, fmt.Errorf("%w: %s", err, s)
+		}
+	}
+
+	tc.frames, tc.subFrame = frames, subFrame
+	if negative {
+		tc = tc.negate()
+	}
+
+	tc.frames, err = rate.bound(tc.frames)
+	if err != nil {
+		return tc, fmt.Errorf("%w: %s", err, s)
+	}
+
+	return tc, nil
+}
+
+// layoutFrames returns the number of frames for the values parsed by ParseWithLayout.
+func layoutFrames(rate Rate, has [256]bool, values [256]uint64, fraction *big.Rat, options parseOptions) (int64, error) {
+	timeBase := uint64(rate.timeBase)
+	hour, minute, second, frame := values['H'], values['M'], values['S'], values['F']
+
+	if has['H'] && minute >= 60 {
+		return 0, fmt.Errorf("minutes must be between 0 and 59 got: %d", minute)
+	}
+	if (has['H'] || has['M']) && second >= 60 {
+		return 0, fmt.Errorf("seconds must be between 0 and 59 got: %d", second)
+	}
+
+	// the total number of seconds carried by the clock verbs
+	total := new(big.Int).SetUint64(hour)
+	total.Mul(total, big.NewInt(60)).Add(total, new(big.Int).SetUint64(minute))
+	total.Mul(total, big.NewInt(60)).Add(total, new(big.Int).SetUint64(second))
+
+	switch {
+	case has['s'] || has['L']:
+		elapsed := new(big.Rat).Add(new(big.Rat).SetInt(total), fraction)
+		elapsed.Mul(elapsed, rate.Ratio())
+		frames := round(elapsed, RoundNearest)
+		if !frames.IsInt64() {
+			return 0, ErrOverflow
+		}
+		return frames.Int64(), nil
+	case has['K']:
+		if has['F'] && frame >= framesPerFoot35mm {
+			return 0, fmt.Errorf("frames must be between 0 and %d got: %d", framesPerFoot35mm-1, frame)
+		}
+		frames := new(big.Int).SetUint64(values['K'])
+		frames.Mul(frames, big.NewInt(framesPerFoot35mm)).Add(frames, new(big.Int).SetUint64(frame))
+		if !frames.IsInt64() {
+			return 0, ErrOverflow
+		}
+		return frames.Int64(), nil
+	case !has['H'] && !has['M'] && !has['S']:
+		if frame > math.MaxInt64 {
+			return 0, ErrOverflow
+		}
+		return int64(frame), nil
+	}
+
+	if has['S'] && frame >= timeBase {
+		return 0, fmt.Errorf("frames must be between 0 and %f got: %d", rate.FPS(), frame)
+	}
+	total.Add(total, new(big.Int).SetUint64(frame/timeBase))
+	frame %= timeBase
+
+	if !total.IsUint64() || total.Uint64()/3600 > math.MaxInt64 {
+		return 0, ErrOverflow
+	}
+	hour, minute, second = total.Uint64()/3600, total.Uint64()/60%60, total.Uint64()%60
+
+	if isDroppedLabel(rate, minute, second, frame) {
+		if !options.snapDroppedFrames {
+			return 0, ErrDroppedFrame
+		}
+		frame = uint64(rate.dropFrames())
+	}
+
+	return labelToFrames(rate, hour, minute, second, frame)
+}
+
+// isNumberVerb returns true if verb is replaced by a number.
+func isNumberVerb(verb byte) bool {
+	return verb != 0 && verb != ';' && verb != '.'
+}
+
+func bigInt(digits string) *big.Int {
+	i, _ := new(big.Int).SetString(digits, 10)
+	return i
+}
+
+// parseLayout splits layout into verbs and literal text.
+func parseLayout(layout string) []layoutItem {
+	items := []layoutItem{}
+	literal := strings.Builder{}
+
+	for i := 0; i < len(layout); i++ {
+		if layout[i] != '%' || i+1 == len(layout) {
+			literal.WriteByte(layout[i])
+			continue
+		}
+
+		j := i + 1
+		for j < len(layout) && layout[j] >= '0' && layout[j] <= '9' {
+			j++
+		}
+		if j == len(layout) {
+			literal.WriteString(layout[i:])
+			break
+		}
+
+		verb := layout[j]
+		width := defaultWidth(verb)
+		if j > i+1 {
+			width, _ = strconv.Atoi(layout[i+1 : j])
+		}
+
+		switch verb {
+		case 'H', 'M', 'S', 'F', 'U', 'L', 'K', 's', ';', '.':
+			if literal.Len() > 0 {
+				items = append(items, layoutItem{literal: literal.String()})
+				literal.Reset()
+			}
+			items = append(items, layoutItem{verb: verb, width: width})
+		case '%':
+			literal.WriteByte('%')
+		default:
+			// unknown verbs are kept as literal text
+			literal.WriteString(layout[i : j+1])
+		}
+		i = j
+	}
+
+	if literal.Len() > 0 {
+		items = append(items, layoutItem{literal: literal.String()})
+	}
+
+	return items
+}
+
+// defaultWidth returns the minimum number of digits used for verb when the layout doesn't set one.
+func defaultWidth(verb byte) int {
+	switch verb {
+	case 'L':
+		return 3
+	case 'K', 's':
+		return 1
+	default:
+		return 2
+	}
+}
+
+// writePadded writes v to sb left padded with zeros to at least width digits.
+func writePadded(sb *strings.Builder, v uint64, width int) {
+	digits := strconv.FormatUint(v, 10)
+	for i := len(digits); i < width; i++ {
+		sb.WriteByte('0')
+	}
+	sb.WriteString(digits)
+}
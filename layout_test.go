@@ -0,0 +1,205 @@
+package timecode
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormat(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		rate     Rate
+		timecode string
+		layout   string
+		expected string
+	}{
+		{R30, "01:30:12:15", LayoutSMPTE, "01:30:12:15"},
+		{R2997DF, "01:30:12;15", LayoutSMPTE, "01:30:12;15"},
+		{R30, "01:30:12:15", LayoutSMPTEDot, "01:30:12.15"},
+		{R2997DF, "01:30:12;15", LayoutSMPTEDot, "01:30:12,15"},
+		{R30, "-01:30:12:15", LayoutSMPTE, "-01:30:12:15"},
+		{R30, "123:00:10:00", LayoutSMPTE, "123:00:10:00"},
+
+		{R30, "01:30:12:15", LayoutFFmpeg, "01:30:12.500"},
+		{R30, "01:30:12:15", LayoutSRT, "01:30:12,500"},
+		{R25, "00:00:00:01", LayoutFFmpeg, "00:00:00.040"},
+		{R2997DF, "01:30:12;15", LayoutFFmpeg, "01:30:12.507"},
+		{R2997, "01:30:12:15", LayoutSRT, "01:30:17,912"},
+		{R2398, "-00:00:01:00", LayoutFFmpeg, "-00:00:01.001"},
+
+		{R30, "01:30:12:15", LayoutFrames, "162375"},
+		{R2997DF, "01:30:12;15", LayoutFrames, "162213"},
+		{R30, "00:00:00:05", LayoutFrames, "5"},
+		{R30, "-00:00:00:05", LayoutFrames, "-5"},
+
+		{R24, "00:00:10:00", LayoutFeetFrames, "15+00"},
+		{R24, "00:00:10:01", LayoutFeetFrames, "15+01"},
+		{R24, "01:30:12:15", LayoutFeetFrames, "8118+15"},
+		{R24, "00:00:00:08", LayoutFeetFrames, "0+08"},
+
+		{R30, "01:30:12:15", "%3H:%M:%S:%F", "001:30:12:15"},
+		{R30, "01:30:12:15", "%M:%S%;%F", "90:12:15"},
+		{R30, "01:30:12:15", "%S.%F", "5412.15"},
+		{R30, "01:30:12:15", "%H:%M", "01:30"},
+		{R30, "00:01:02:03", "%1M:%S:%F", "1:02:03"},
+		{R30, "01:30:12:15", "%Hh%Mm%Ss%Ff", "01h30m12s15f"},
+		{R30, "01:30:12:15", "%S.%L", "5412.500"},
+		{R30, "01:30:12:15", "%M:%F", "90:375"},
+		{R30, "01:30:12:15", "100%% %H %X %3", "100% 01 %X %3"},
+		{R30, "01:30:12:15", "", ""},
+	}
+
+	for _, test := range tests {
+		tc, err := Parse(test.rate, test.timecode)
+		assert.Nil(t, err)
+		assert.Equal(t, test.expected, tc.Format(test.layout), test.timecode+" "+test.layout)
+	}
+}
+
+func TestStringMatchesLayoutSMPTE(t *testing.T) {
+	t.Parallel()
+
+	for _, rate := range []Rate{R30, R2997DF, R5994DF, R25, R2398} {
+		for frames := int64(-50000); frames < 50000; frames += 331 {
+			tc := FromFrames(rate, frames)
+			assert.Equal(t, tc.String(), tc.Format(LayoutSMPTE))
+
+			parsed, err := Parse(rate, tc.Format(LayoutSMPTEDot))
+			assert.Nil(t, err)
+			assert.Equal(t, tc, parsed)
+		}
+	}
+}
+
+func TestFormatSeconds(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "5412.5s", FromFrames(R30, 162375).Format(LayoutSeconds))
+	assert.Equal(t, "5412.507s", FromFrames(R2997DF, 162213).Format(LayoutSeconds))
+	assert.Equal(t, "10s", FromFrames(R25, 250).Format(LayoutSeconds))
+	assert.Equal(t, "0.04s", FromFrames(R25, 1).Format(LayoutSeconds))
+	assert.Equal(t, "-1.48s", FromFrames(R25, -37).Format(LayoutSeconds))
+}
+
+func TestParseAny(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		rate     Rate
+		s        string
+		expected string
+	}{
+		{R30, "01:30:12:15", "01:30:12:15"},
+		{R2997DF, "01:30:12;15", "01:30:12;15"},
+		{R30, "01:30:12.500", "01:30:12:15"},
+		{R30, "01:30:12,500", "01:30:12:15"},
+		{R30, "01:30:12.5", "01:30:12:05"},
+		{R30, "01:30:12.05", "01:30:12:05"},
+		{R25, "00:00:00.019", "00:00:00:00"},
+		{R25, "00:00:00.020", "00:00:00:01"},
+		{R25, "00:00:00.021", "00:00:00:01"},
+		{R2997DF, "01:30:12.507", "01:30:12;15"},
+		{R2997, "01:30:17,912", "01:30:12:15"},
+		{R30, "90:12:15", "01:30:12:15"},
+		{R2997DF, "90:12;15", "01:30:12;15"},
+		{R30, "00:01:02", "00:00:01:02"},
+		{R30, "5412.5s", "01:30:12:15"},
+		{R30, "10s", "00:00:10:00"},
+		{R2398, "123.5s", "00:02:03:09"},
+		{R30, "162375", "01:30:12:15"},
+		{R2997DF, "162213", "01:30:12;15"},
+		{R30, "-15", "-00:00:00:15"},
+		{R30, "-0.5s", "-00:00:00:15"},
+		{R30, "-00:00:01.000", "-00:00:01:00"},
+	}
+
+	for _, test := range tests {
+		tc, err := ParseAny(test.rate, test.s)
+		assert.Nil(t, err, test.s)
+		assert.Equal(t, test.expected, tc.String(), test.s)
+	}
+
+	for _, s := range []string{"", "abc", "01:30", "01:30:12.", "1.5", "s", "01:60:00.000", "00:00:60,000", "5412.5 s", "--1", "10;00;00"} {
+		_, err := ParseAny(R30, s)
+		assert.NotNil(t, err, s)
+	}
+
+	_, err := ParseAny(R2997DF, "01:00;00")
+	assert.True(t, errors.Is(err, ErrDroppedFrame))
+
+	tc, err := ParseAny(R2997DF, "01:00;00", SnapDroppedFrames())
+	assert.Nil(t, err)
+	assert.Equal(t, "00:01:00;02", tc.String())
+}
+
+func TestParseWithLayout(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		rate     Rate
+		layout   string
+		s        string
+		expected string
+	}{
+		{R30, LayoutSMPTE, "01:30:12:15", "01:30:12:15"},
+		{R30, LayoutSMPTE, "01:30:12;15", "01:30:12:15"},
+		{R30, LayoutSMPTEDot, "01:30:12,15", "01:30:12:15"},
+		{R30, "%H%M%S%F", "01301215", "01:30:12:15"},
+		{R30, "%3H%M%S%F", "001301215", "01:30:12:15"},
+		{R30, "%Hh%Mm%Ss%Ff", "01h30m12s15f", "01:30:12:15"},
+		{R30, "%M:%F", "90:375", "01:30:12:15"},
+		{R30, "%S.%F", "5412.15", "01:30:12:15"},
+		{R24, LayoutFeetFrames, "8118+15", "01:30:12:15"},
+		{R24, LayoutFeetFrames, "0+08", "00:00:00:08"},
+		{R30, "100%% %1F", "100% 15", "00:00:00:15"},
+	}
+
+	for _, test := range tests {
+		tc, err := ParseWithLayout(test.rate, test.layout, test.s)
+		assert.Nil(t, err, test.s)
+		assert.Equal(t, test.expected, tc.String(), test.s)
+	}
+
+	errorTests := []struct {
+		rate   Rate
+		layout string
+		s      string
+	}{
+		{R30, LayoutSMPTE, "01:30:12.15"},
+		{R30, LayoutSMPTE, "01:60:12:15"},
+		{R30, LayoutSMPTE, "01:30:60:15"},
+		{R30, LayoutSMPTE, "01:30:12:30"},
+		{R30, LayoutSMPTE, "01:30:12:15 "},
+		{R24, LayoutFeetFrames, "10+16"},
+		{R30, "%Hh", "h"},
+		{R30, LayoutFrames, "99999999999999999999"},
+	}
+
+	for _, test := range errorTests {
+		_, err := ParseWithLayout(test.rate, test.layout, test.s)
+		assert.NotNil(t, err, test.s)
+	}
+
+	_, err := ParseWithLayout(R30.WithWrap(WrapError), LayoutSeconds, "86400s")
+	assert.True(t, errors.Is(err, ErrOverflow))
+}
+
+func TestLayoutRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	layouts := []string{LayoutSMPTE, LayoutSMPTEDot, LayoutFFmpeg, LayoutSRT, LayoutFrames, LayoutFeetFrames, LayoutMinutes, LayoutSeconds}
+
+	for _, rate := range []Rate{R30, R2997, R2997DF, R5994DF, R25, R2398, R240} {
+		for _, layout := range layouts {
+			for frames := int64(-50000); frames < 50000; frames += 331 {
+				tc := FromFrames(rate, frames)
+				parsed, err := ParseWithLayout(rate, layout, tc.Format(layout))
+				assert.Nil(t, err)
+				assert.Equal(t, tc, parsed, layout+" "+tc.Format(layout))
+			}
+		}
+	}
+}
@@ -0,0 +1,326 @@
+package ltc
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+
+	timecode "github.com/agorman/go-timecode/v2"
+)
+
+const (
+	// minThreshold is the lowest level a sample must pass to count as a change of level so silence and
+	// low level noise aren't decoded.
+	minThreshold = 0.001
+
+	// envelopeDecay is how quickly the tracked peak level falls each sample so the threshold follows changes
+	// of level in the recording.
+	envelopeDecay = 0.9995
+
+	// periodGain is how much of each measured bit cell is taken into the tracked bit period so changes of
+	// speed are followed without being thrown off by a single bad cell.
+	periodGain = 0.25
+)
+
+// DecodedFrame is a Frame found in an LTC audio signal by a Decoder.
+type DecodedFrame struct {
+	Frame
+
+	// Offset is the sample where the codeword starts counted from the first sample passed to the Decoder.
+	// When playing in reverse the codeword is received last bit first so this is the end of the frame in
+	// timecode order.
+	Offset int64
+
+	// Reverse is true if the codeword was played in reverse.
+	Reverse bool
+
+	// Speed is the speed of play compared to the Rate such as 1 for normal play and 2 for twice as fast.
+	Speed float64
+}
+
+// Decoder finds LTC codewords in an audio signal. The signal is passed in chunks of samples and frames are
+// returned as each codeword is completed so a signal of any length can be decoded. The bit period is
+// tracked from the signal so changes of speed such as a tape spinning up are followed and codewords played
+// in reverse are found. Codewords that don't decode to a valid Frame for the Rate are skipped. A Decoder
+// isn't safe for concurrent use.
+type Decoder struct {
+	rate          timecode.Rate
+	nominalPeriod float64
+
+	// level detection
+	envelope float64
+	high     bool
+	started  bool
+	samples  int64
+
+	// bit cell detection
+	period     float64
+	transition int64
+	pending    bool
+	halfLength int64
+
+	// the last WordBits bits received oldest first with the sample each bit started at
+	bits   [WordBits]bool
+	starts [WordBits]int64
+	count  int
+
+	// leftover holds the bytes of a sample split between reads by DecodeReader
+	leftover []byte
+}
+
+// NewDecoder returns a Decoder for LTC at rate in a signal with sampleRate samples per second. An
+// ErrUnsupportedRate error is returned if rate is over 30 fps and an error is returned if the sample rate
+// gives less than one sample per half bit cell.
+func NewDecoder(rate timecode.Rate, sampleRate int64) (*Decoder, error) {
+	if _, err := layoutFor(rate); err != nil {
+		return nil, err
+	}
+
+	if sampleRate < 1 || sampleRate*rate.Den() < halfCells*rate.Num() {
+		return nil, fmt.Errorf("sample rate is too low for ltc at %s got: %d", rate, sampleRate)
+	}
+
+	period := float64(sampleRate*rate.Den()) / float64(WordBits*rate.Num())
+	return &Decoder{
+		rate:          rate,
+		nominalPeriod: period,
+		period:        period,
+	}, nil
+}
+
+// DecodeFloat32 decodes the samples which follow the samples already passed to the Decoder and returns the
+// frames completed by them.
+func (d *Decoder) DecodeFloat32(samples []float32) []DecodedFrame {
+	var frames []DecodedFrame
+	for _, sample := range samples {
+		frames = d.decodeSample(float64(sample), frames)
+	}
+	return frames
+}
+
+// DecodeInt16 decodes the 16 bit PCM samples which follow the samples already passed to the Decoder and
+// returns the frames completed by them.
+func (d *Decoder) DecodeInt16(samples []int16) []DecodedFrame {
+	var frames []DecodedFrame
+	for _, sample := range samples {
+		frames = d.decodeSample(float64(sample)/-math.MinInt16, frames)
+	}
+	return frames
+}
+
+// Flush decodes the last bit of the signal and returns the frame it completes if any. A 0 bit is only found
+// at the next change of level so Flush should be called once the signal has ended to find a codeword
+// ending in a 0 bit, such as the last codeword when playing in reverse. The Decoder can decode a new signal
+// afterwards and its Offsets carry on counting from the samples already passed.
+func (d *Decoder) Flush() []DecodedFrame {
+	frames := d.decodeLastBit(d.transition, d.samples-d.transition, nil)
+
+	d.started = false
+	d.pending = false
+	d.count = 0
+	return frames
+}
+
+// DecodeReader decodes 16 bit little endian mono PCM read from r until the end of r and calls fn with each
+// frame as it's completed. The Decoder is flushed at the end of r. Decoding stops at the first error
+// returned by r or fn which is returned. The end of r isn't an error unless it splits a sample in which
+// case io.ErrUnexpectedEOF is returned once the frames before it have been passed to fn.
+func (d *Decoder) DecodeReader(r io.Reader, fn func(DecodedFrame) error) error {
+	return d.decodeReader(r, 2, func(b []byte) float64 {
+		return float64(int16(binary.LittleEndian.Uint16(b))) / -math.MinInt16
+	}, fn)
+}
+
+// DecodeReaderFloat32 decodes 32 bit little endian IEEE 754 mono samples read from r the same as
+// DecodeReader.
+func (d *Decoder) DecodeReaderFloat32(r io.Reader, fn func(DecodedFrame) error) error {
+	return d.decodeReader(r, 4, func(b []byte) float64 {
+		return float64(math.Float32frombits(binary.LittleEndian.Uint32(b)))
+	}, fn)
+}
+
+// decodeReader decodes samples of size bytes read from r using sample to read each one.
+func (d *Decoder) decodeReader(r io.Reader, size int, sample func([]byte) float64, fn func(DecodedFrame) error) error {
+	buf := make([]byte, 8192)
+
+	for {
+		n := copy(buf, d.leftover)
+		read, err := r.Read(buf[n:])
+		n += read
+
+		var frames []DecodedFrame
+		count := n / size
+		for i := 0; i < count; i++ {
+			frames = d.decodeSample(sample(buf[i*size:]), frames)
+		}
+		d.leftover = append(d.leftover[:0], buf[count*size:n]...)
+
+		if err == io.EOF {
+			frames = append(frames, d.Flush()...)
+		}
+
+		for _, frame := range frames {
+			if err := fn(frame); err != nil {
+				return err
+			}
+		}
+
+		if err == io.EOF {
+			if len(d.leftover) > 0 {
+				d.leftover = d.leftover[:0]
+				return io.ErrUnexpectedEOF
+			}
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// decodeSample passes the next sample through the level detection and appends any completed frame to
+// frames.
+func (d *Decoder) decodeSample(sample float64, frames []DecodedFrame) []DecodedFrame {
+	position := d.samples
+	d.samples++
+
+	level := math.Abs(sample)
+	d.envelope *= envelopeDecay
+	if level > d.envelope {
+		d.envelope = level
+	}
+
+	threshold := math.Max(d.envelope/10, minThreshold)
+	switch {
+	case sample > threshold && (!d.high || !d.started):
+		d.high = true
+	case sample < -threshold && (d.high || !d.started):
+		d.high = false
+	default:
+		return frames
+	}
+
+	if !d.started {
+		// the first change of level starts the first bit cell
+		d.started = true
+		d.transition = position
+		return frames
+	}
+
+	return d.decodeTransition(position, frames)
+}
+
+// decodeTransition measures the time since the last change of level at position to find the bits of the
+// biphase mark code and appends any completed frame to frames.
+func (d *Decoder) decodeTransition(position int64, frames []DecodedFrame) []DecodedFrame {
+	length := position - d.transition
+	start := d.transition
+	d.transition = position
+
+	switch interval := float64(length); {
+	case interval > d.period*4:
+		// a gap in the signal so start again once the 0 bit it ends is decoded
+		frames = d.decodeLastBit(start, length, frames)
+		d.pending = false
+		d.count = 0
+		return frames
+	case interval > d.period*0.75:
+		// a full cell is a 0 bit and ends a half cell that wasn't followed by another
+		d.pending = false
+		d.trackPeriod(interval)
+		return d.decodeBit(false, start, frames)
+	case !d.pending:
+		// the change of level in the middle of a cell is a 1 bit so the last bit of the sync word is found
+		// without waiting for the next codeword
+		d.pending = true
+		d.halfLength = length
+		return d.decodeBit(true, start, frames)
+	default:
+		d.pending = false
+		d.trackPeriod(float64(d.halfLength + length))
+		return frames
+	}
+}
+
+// decodeLastBit decodes the 0 bit that started at sample start when the signal ends or a gap follows after
+// length samples. A 0 bit has no change of level until the next cell so it's found this way rather than
+// from the change of level, such as the last bit of a codeword played in reverse.
+func (d *Decoder) decodeLastBit(start, length int64, frames []DecodedFrame) []DecodedFrame {
+	if !d.started || d.pending || float64(length) <= d.period*0.75 {
+		return frames
+	}
+	return d.decodeBit(false, start, frames)
+}
+
+// trackPeriod takes a measured bit cell into the tracked bit period.
+func (d *Decoder) trackPeriod(measured float64) {
+	d.period += (measured - d.period) * periodGain
+}
+
+// decodeBit adds a bit that started at sample start and appends the frame to frames if the bit completes
+// a codeword in either direction.
+func (d *Decoder) decodeBit(bit bool, start int64, frames []DecodedFrame) []DecodedFrame {
+	if d.count == WordBits {
+		copy(d.bits[:], d.bits[1:])
+		copy(d.starts[:], d.starts[1:])
+		d.count--
+	}
+	d.bits[d.count] = bit
+	d.starts[d.count] = start
+	d.count++
+
+	if d.count < WordBits {
+		return frames
+	}
+
+	var w Word
+	var reverse bool
+	switch {
+	case d.matchSync(WordBits-16, false):
+		for i, bit := range d.bits {
+			w.setBit(i, bit)
+		}
+	case d.matchSync(0, true):
+		reverse = true
+		for i, bit := range d.bits {
+			w.setBit(WordBits-1-i, bit)
+		}
+	default:
+		return frames
+	}
+
+	frame, err := Decode(w, d.rate)
+	if err != nil {
+		return frames
+	}
+
+	// the bits are used so the next codeword starts fresh
+	offset := d.starts[0]
+	d.count = 0
+
+	return append(frames, DecodedFrame{
+		Frame:   frame,
+		Offset:  offset,
+		Reverse: reverse,
+		Speed:   d.nominalPeriod / d.period,
+	})
+}
+
+// matchSync returns true if the 16 bits starting at bit start of the received bits are the sync word in
+// the order it's sent or in reverse.
+func (d *Decoder) matchSync(start int, reverse bool) bool {
+	sync := Word{8: syncWord[0], 9: syncWord[1]}
+
+	for i := 0; i < 16; i++ {
+		bit := WordBits - 16 + i
+		if reverse {
+			bit = WordBits - 1 - i
+		}
+
+		if d.bits[start+i] != sync.Bit(bit) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,292 @@
+package ltc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"testing"
+	"testing/iotest"
+
+	timecode "github.com/agorman/go-timecode/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+// generate returns frames frames of LTC starting at start as float32 samples.
+func generate(t *testing.T, start Frame, sampleRate int64, level float64, frames int) []float32 {
+	g, err := NewGenerator(start, sampleRate, level)
+	assert.Nil(t, err)
+
+	var buf bytes.Buffer
+	assert.Nil(t, g.WriteWAV(&buf, frames))
+
+	samples := make([]float32, (buf.Len()-44)/2)
+	g, err = NewGenerator(start, sampleRate, level)
+	assert.Nil(t, err)
+	_, err = g.ReadFloat32(samples)
+	assert.Nil(t, err)
+	return samples
+}
+
+// frameStart returns the first sample of frame n of a signal starting at sample 0.
+func frameStart(rate timecode.Rate, sampleRate int64, n int64) int64 {
+	return (n*sampleRate*rate.Den() + rate.Num() - 1) / rate.Num()
+}
+
+func TestDecoder(t *testing.T) {
+	t.Parallel()
+
+	for _, rate := range []timecode.Rate{timecode.R24, timecode.R25, timecode.R2997DF, timecode.R30} {
+		start := Frame{
+			Timecode:   mustParse(rate, "00:59:59:00"),
+			UserBits:   0xdeadbeef,
+			ColorFrame: true,
+			Flags:      BGF0 | BGF2,
+		}
+		samples := generate(t, start, 48000, 0.5, 100)
+
+		d, err := NewDecoder(rate, 48000)
+		assert.Nil(t, err)
+
+		// the samples are passed in chunks that split codewords
+		var frames []DecodedFrame
+		for i := 0; i < len(samples); i += 1000 {
+			end := i + 1000
+			if end > len(samples) {
+				end = len(samples)
+			}
+			frames = append(frames, d.DecodeFloat32(samples[i:end])...)
+		}
+
+		assert.Equal(t, 100, len(frames), "%s", rate)
+		for i, frame := range frames {
+			assert.Equal(t, start.Timecode.Frames()+int64(i), frame.Timecode.Frames(), "%s", rate)
+			assert.Equal(t, start.UserBits, frame.UserBits)
+			assert.Equal(t, start.ColorFrame, frame.ColorFrame)
+			assert.Equal(t, start.Flags, frame.Flags)
+			assert.Equal(t, frameStart(rate, 48000, int64(i)), frame.Offset, "%s", rate)
+			assert.False(t, frame.Reverse)
+			assert.InDelta(t, 1, frame.Speed, 0.01)
+		}
+	}
+}
+
+func TestDecoderReverse(t *testing.T) {
+	t.Parallel()
+
+	rate := timecode.R2997DF
+	start := Frame{Timecode: mustParse(rate, "10:00:00;00"), UserBits: 0x12345678}
+	samples := generate(t, start, 48000, 0.5, 30)
+
+	reversed := make([]float32, len(samples))
+	for i, sample := range samples {
+		reversed[len(samples)-1-i] = sample
+	}
+
+	d, err := NewDecoder(rate, 48000)
+	assert.Nil(t, err)
+
+	// the last codeword ends in a 0 bit which is only found once the signal has ended
+	frames := d.DecodeFloat32(reversed)
+	assert.Equal(t, 29, len(frames))
+	frames = append(frames, d.Flush()...)
+	assert.Equal(t, 30, len(frames))
+	for i, frame := range frames {
+		n := int64(29 - i)
+		assert.Equal(t, start.Timecode.Frames()+n, frame.Timecode.Frames())
+		assert.Equal(t, start.UserBits, frame.UserBits)
+		assert.Equal(t, int64(len(samples))-frameStart(rate, 48000, n+1), frame.Offset)
+		assert.True(t, frame.Reverse)
+	}
+}
+
+func TestDecoderReverseGap(t *testing.T) {
+	t.Parallel()
+
+	rate := timecode.R25
+	var signal []float32
+	for _, label := range []string{"10:00:00:00", "10:00:00:05"} {
+		samples := generate(t, Frame{Timecode: mustParse(rate, label)}, 48000, 0.5, 5)
+		for i := len(samples) - 1; i >= 0; i-- {
+			signal = append(signal, samples[i])
+		}
+		signal = append(signal, make([]float32, 4800)...)
+	}
+
+	d, err := NewDecoder(rate, 48000)
+	assert.Nil(t, err)
+
+	// the gap ends the last codeword of the first burst the same as Flush
+	frames := d.DecodeFloat32(signal)
+	frames = append(frames, d.Flush()...)
+	assert.Equal(t, 10, len(frames))
+
+	var labels []string
+	for _, frame := range frames {
+		labels = append(labels, frame.Timecode.String())
+		assert.True(t, frame.Reverse)
+	}
+	assert.Equal(t, "10:00:00:04", labels[0])
+	assert.Equal(t, "10:00:00:00", labels[4])
+	assert.Equal(t, "10:00:00:09", labels[5])
+	assert.Equal(t, "10:00:00:05", labels[9])
+}
+
+func TestDecoderSpeed(t *testing.T) {
+	t.Parallel()
+
+	rate := timecode.R25
+	start := Frame{Timecode: mustParse(rate, "01:00:00:00")}
+	samples := generate(t, start, 96000, 0.5, 200)
+
+	// play back at a speed that varies between 0.8 and 1.2 with a little noise
+	var varied []float32
+	for position, i := 0.0, 0; int(position) < len(samples); i++ {
+		noise := float32(math.Sin(float64(i)*1.7)) * 0.02
+		varied = append(varied, samples[int(position)]+noise)
+		position += 1 + 0.2*math.Sin(float64(i)/5000)
+	}
+
+	d, err := NewDecoder(rate, 96000)
+	assert.Nil(t, err)
+
+	frames := d.DecodeFloat32(varied)
+	assert.True(t, len(frames) >= 195, "%d frames", len(frames))
+	for i := 1; i < len(frames); i++ {
+		assert.True(t, frames[i].Timecode.After(frames[i-1].Timecode))
+		assert.True(t, frames[i].Offset > frames[i-1].Offset)
+	}
+
+	speed := frames[len(frames)/4].Speed
+	assert.True(t, speed > 0.7 && speed < 1.3, "speed %g", speed)
+
+	for _, speed := range []float64{0.5, 2} {
+		var played []float32
+		for position := 0.0; int(position) < len(samples); position += speed {
+			played = append(played, samples[int(position)])
+		}
+
+		d, err := NewDecoder(rate, 96000)
+		assert.Nil(t, err)
+
+		frames := d.DecodeFloat32(played)
+		assert.True(t, len(frames) >= 195, "%d frames at %g", len(frames), speed)
+		assert.InDelta(t, speed, frames[len(frames)-1].Speed, 0.05)
+	}
+}
+
+func TestDecodeReader(t *testing.T) {
+	t.Parallel()
+
+	rate := timecode.R2997DF
+	g, err := NewGenerator(Frame{Timecode: mustParse(rate, "01:00:00;00")}, 48000, 0.1)
+	assert.Nil(t, err)
+
+	var buf bytes.Buffer
+	assert.Nil(t, g.WriteWAV(&buf, 10))
+
+	d, err := NewDecoder(rate, 48000)
+	assert.Nil(t, err)
+
+	var labels []string
+	err = d.DecodeReader(iotest.OneByteReader(bytes.NewReader(buf.Bytes()[44:])), func(frame DecodedFrame) error {
+		labels = append(labels, frame.Timecode.String())
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 10, len(labels))
+	assert.Equal(t, "01:00:00;00", labels[0])
+	assert.Equal(t, "01:00:00;09", labels[9])
+
+	stop := errors.New("stop")
+	d, err = NewDecoder(rate, 48000)
+	assert.Nil(t, err)
+	err = d.DecodeReader(bytes.NewReader(buf.Bytes()[44:]), func(frame DecodedFrame) error {
+		return stop
+	})
+	assert.Equal(t, stop, err)
+
+	d, err = NewDecoder(rate, 48000)
+	assert.Nil(t, err)
+	err = d.DecodeReader(iotest.TimeoutReader(bytes.NewReader(buf.Bytes())), func(frame DecodedFrame) error {
+		return nil
+	})
+	assert.True(t, errors.Is(err, iotest.ErrTimeout))
+
+	d, err = NewDecoder(rate, 48000)
+	assert.Nil(t, err)
+	err = d.DecodeReader(bytes.NewReader(buf.Bytes()[43:]), func(frame DecodedFrame) error {
+		return nil
+	})
+	assert.Equal(t, io.ErrUnexpectedEOF, err)
+}
+
+func TestDecodeReaderFloat32(t *testing.T) {
+	t.Parallel()
+
+	rate := timecode.R25
+	samples := generate(t, Frame{Timecode: mustParse(rate, "01:00:00:00")}, 48000, 0.5, 10)
+
+	var buf bytes.Buffer
+	for _, sample := range samples {
+		assert.Nil(t, binary.Write(&buf, binary.LittleEndian, sample))
+	}
+
+	d, err := NewDecoder(rate, 48000)
+	assert.Nil(t, err)
+
+	var labels []string
+	err = d.DecodeReaderFloat32(iotest.HalfReader(bytes.NewReader(buf.Bytes())), func(frame DecodedFrame) error {
+		labels = append(labels, frame.Timecode.String())
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 10, len(labels))
+	assert.Equal(t, "01:00:00:00", labels[0])
+	assert.Equal(t, "01:00:00:09", labels[9])
+
+	// a sample cut short by the end of r is an error once the frames before it are decoded
+	d, err = NewDecoder(rate, 48000)
+	assert.Nil(t, err)
+	labels = nil
+	err = d.DecodeReaderFloat32(bytes.NewReader(append(buf.Bytes(), 0, 0)), func(frame DecodedFrame) error {
+		labels = append(labels, frame.Timecode.String())
+		return nil
+	})
+	assert.Equal(t, io.ErrUnexpectedEOF, err)
+	assert.Equal(t, 10, len(labels))
+}
+
+func TestDecoderSkips(t *testing.T) {
+	t.Parallel()
+
+	samples := generate(t, Frame{Timecode: mustParse(timecode.R25, "01:00:00:00")}, 48000, 0.5, 10)
+
+	// a gap of silence loses the frames it cuts
+	gapped := append([]float32{}, samples[:1920*3+500]...)
+	gapped = append(gapped, make([]float32, 2000)...)
+	gapped = append(gapped, samples[1920*5:]...)
+
+	d, err := NewDecoder(timecode.R25, 48000)
+	assert.Nil(t, err)
+	frames := d.DecodeFloat32(gapped)
+	assert.Equal(t, 3+5, len(frames))
+	assert.Equal(t, "01:00:00:02", frames[2].Timecode.String())
+	assert.Equal(t, "01:00:00:05", frames[3].Timecode.String())
+	assert.Equal(t, 0, len(d.Flush()))
+
+	// a rate with a different drop frame flag doesn't decode
+	d, err = NewDecoder(timecode.R2997DF, 48000)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(d.DecodeInt16(make([]int16, 1000))))
+
+	samples = generate(t, Frame{Timecode: timecode.FromFrames(timecode.R2997, 0)}, 48000, 0.5, 10)
+	assert.Equal(t, 0, len(d.DecodeFloat32(samples)))
+
+	_, err = NewDecoder(timecode.R50, 48000)
+	assert.True(t, errors.Is(err, ErrUnsupportedRate))
+
+	_, err = NewDecoder(timecode.R30, 4000)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,219 @@
+package ltc
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+
+	timecode "github.com/agorman/go-timecode/v2"
+)
+
+// halfCells is the number of half bit cells in a codeword. Biphase mark coding changes level at the start
+// of every bit and in the middle of each 1 bit so the signal is generated a half cell at a time.
+const halfCells = 2 * WordBits
+
+// Generator generates the LTC audio signal for a run of frames starting at a Frame. The signal is biphase
+// mark coded as a square wave at a sample rate and peak level. Each frame lasts exactly its share of the
+// Rate so at 29.97 fps and 48 kHz frames alternate between 1601 and 1602 samples and stay locked to the
+// samples of the audio. The user bits and flags of the starting Frame are repeated in every frame and the
+// Timecode wraps at 24 hours like a hardware generator. A Generator isn't safe for concurrent use.
+type Generator struct {
+	frame      Frame
+	word       Word
+	sampleRate int64
+	level      float64
+
+	// the sample clock advances step every sample and a half cell lasts period
+	period int64
+	step   int64
+	phase  int64
+
+	cell    int
+	frames  int64
+	samples int64
+	high    bool
+}
+
+// NewGenerator returns a Generator starting at the frame start. The sample rate must give at least one
+// sample per half bit cell, such as 48000 for 30 fps, and level is the peak amplitude of the signal
+// between 0 and 1. An ErrUnsupportedRate error is returned if the Rate of the Timecode is over 30 fps and
+// an error is returned if the frame can't be encoded.
+func NewGenerator(start Frame, sampleRate int64, level float64) (*Generator, error) {
+	if level <= 0 || level > 1 {
+		return nil, fmt.Errorf("level must be greater than 0 and at most 1 got: %g", level)
+	}
+
+	word, err := Encode(start)
+	if err != nil {
+		return nil, err
+	}
+
+	rate := start.Timecode.Rate()
+	g := &Generator{
+		frame:      start,
+		word:       word,
+		sampleRate: sampleRate,
+		level:      level,
+		period:     sampleRate * rate.Den(),
+		step:       halfCells * rate.Num(),
+		high:       true,
+	}
+
+	if sampleRate < 1 || g.period < g.step {
+		return nil, fmt.Errorf("sample rate is too low for ltc at %s got: %d", rate, sampleRate)
+	}
+
+	return g, nil
+}
+
+// Frame returns the Frame being generated.
+func (g *Generator) Frame() Frame {
+	return g.frame
+}
+
+// ReadFloat32 fills p with the next samples of the signal and returns the number of samples generated. An
+// error is only returned if the Timecode can't be advanced to the next frame.
+func (g *Generator) ReadFloat32(p []float32) (int, error) {
+	for i := range p {
+		high, err := g.next()
+		if err != nil {
+			return i, err
+		}
+
+		p[i] = float32(g.level)
+		if !high {
+			p[i] = -p[i]
+		}
+	}
+
+	return len(p), nil
+}
+
+// ReadInt16 fills p with the next samples of the signal as 16 bit PCM and returns the number of samples
+// generated. An error is only returned if the Timecode can't be advanced to the next frame.
+func (g *Generator) ReadInt16(p []int16) (int, error) {
+	level := int16(math.Round(g.level * math.MaxInt16))
+
+	for i := range p {
+		high, err := g.next()
+		if err != nil {
+			return i, err
+		}
+
+		p[i] = level
+		if !high {
+			p[i] = -level
+		}
+	}
+
+	return len(p), nil
+}
+
+// WriteWAV writes a 16 bit mono PCM WAV file holding the signal for frames frames starting at the current
+// frame to w. The part of the current frame that was already generated isn't repeated so a new Generator
+// writes exactly frames frames. An error is returned if the file would be larger than 4 GiB.
+func (g *Generator) WriteWAV(w io.Writer, frames int) error {
+	if frames < 0 {
+		return fmt.Errorf("frames must be at least 0 got: %d", frames)
+	}
+
+	// the first sample of a frame is the first sample at or after its start
+	rate := g.frame.Timecode.Rate()
+	end := new(big.Int).Mul(big.NewInt(g.frames+int64(frames)), big.NewInt(g.period))
+	end.Add(end, big.NewInt(rate.Num()-1))
+	end.Div(end, big.NewInt(rate.Num()))
+
+	samples := end.Int64() - g.samples
+	if !end.IsInt64() || samples*2 > math.MaxUint32-36 {
+		return fmt.Errorf("wav file of %d frames is too large", frames)
+	}
+
+	var header [44]byte
+	copy(header[0:], "RIFF")
+	binary.LittleEndian.PutUint32(header[4:], uint32(36+samples*2))
+	copy(header[8:], "WAVEfmt ")
+	binary.LittleEndian.PutUint32(header[16:], 16)
+	binary.LittleEndian.PutUint16(header[20:], 1)
+	binary.LittleEndian.PutUint16(header[22:], 1)
+	binary.LittleEndian.PutUint32(header[24:], uint32(g.sampleRate))
+	binary.LittleEndian.PutUint32(header[28:], uint32(g.sampleRate*2))
+	binary.LittleEndian.PutUint16(header[32:], 2)
+	binary.LittleEndian.PutUint16(header[34:], 16)
+	copy(header[36:], "data")
+	binary.LittleEndian.PutUint32(header[40:], uint32(samples*2))
+
+	if _, err := w.Write(header[:]); err != nil {
+		return err
+	}
+
+	buf := make([]int16, 4096)
+	for samples > 0 {
+		n := int64(len(buf))
+		if samples < n {
+			n = samples
+		}
+
+		if _, err := g.ReadInt16(buf[:n]); err != nil {
+			return err
+		}
+		if err := binary.Write(w, binary.LittleEndian, buf[:n]); err != nil {
+			return err
+		}
+
+		samples -= n
+	}
+
+	return nil
+}
+
+// next returns true if the next sample is high and advances the sample clock.
+func (g *Generator) next() (bool, error) {
+	high := g.high
+
+	g.samples++
+	g.phase += g.step
+	if g.phase < g.period {
+		return high, nil
+	}
+
+	// the period is at least the step so the sample clock crosses at most one half cell
+	g.phase -= g.period
+	g.cell++
+	if g.cell == halfCells {
+		if err := g.nextFrame(); err != nil {
+			return high, err
+		}
+	}
+
+	if g.cell%2 == 0 || g.word.Bit(g.cell/2) {
+		g.high = !g.high
+	}
+
+	return high, nil
+}
+
+// nextFrame advances the Generator to the next frame wrapping at 24 hours. The frame is worked out from
+// the frame count rather than Timecode.Add so the Generator wraps whatever the Wrap mode of the Rate is.
+func (g *Generator) nextFrame() error {
+	rate := g.frame.Timecode.Rate()
+	next := g.frame.Timecode.Frames() + 1
+	if next >= rate.FramesPerDay() {
+		next = 0
+	}
+
+	frame := g.frame
+	frame.Timecode = timecode.FromFrames(rate, next)
+
+	word, err := Encode(frame)
+	if err != nil {
+		return err
+	}
+
+	g.frame = frame
+	g.word = word
+	g.cell = 0
+	g.frames++
+	return nil
+}
@@ -0,0 +1,155 @@
+package ltc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	timecode "github.com/agorman/go-timecode/v2"
+	"github.com/agorman/go-timecode/v2/bwf"
+	"github.com/stretchr/testify/assert"
+)
+
+// demodulate returns the codeword in samples where each half cell lasts exactly halfCell samples.
+func demodulate(samples []float32, halfCell int) Word {
+	var w Word
+	for i := 0; i < WordBits; i++ {
+		start := i * 2 * halfCell
+		// a 1 bit changes level in the middle of the cell
+		w.setBit(i, samples[start] != samples[start+halfCell])
+	}
+	return w
+}
+
+func TestGenerator(t *testing.T) {
+	t.Parallel()
+
+	start := Frame{
+		Timecode: mustParse(timecode.R25, "10:00:00:00"),
+		UserBits: 0x12345678,
+		Flags:    BGF1,
+	}
+
+	g, err := NewGenerator(start, 48000, 0.5)
+	assert.Nil(t, err)
+	assert.Equal(t, start, g.Frame())
+
+	// 48000 / 25 / 160 is exactly 12 samples per half cell
+	samples := make([]float32, 1920*3)
+	n, err := g.ReadFloat32(samples)
+	assert.Nil(t, err)
+	assert.Equal(t, len(samples), n)
+
+	for i := 0; i < 3; i++ {
+		frame := start
+		frame.Timecode = timecode.FromFrames(timecode.R25, start.Timecode.Frames()+int64(i))
+
+		w, err := Encode(frame)
+		assert.Nil(t, err)
+		assert.Equal(t, w, demodulate(samples[i*1920:], 12))
+	}
+
+	assert.Equal(t, "10:00:00:03", g.Frame().Timecode.String())
+	assert.Equal(t, start.UserBits, g.Frame().UserBits)
+
+	// every bit starts with a change of level and the polarity bit keeps the first sample of each frame the
+	// same
+	for i, sample := range samples {
+		assert.True(t, sample == 0.5 || sample == -0.5)
+		if i%24 == 0 && i > 0 {
+			assert.NotEqual(t, samples[i-1], sample)
+		}
+	}
+	assert.Equal(t, samples[0], samples[1920])
+	assert.Equal(t, samples[0], samples[3840])
+
+	pcm := make([]int16, 1920)
+	g, err = NewGenerator(start, 48000, 0.5)
+	assert.Nil(t, err)
+	_, err = g.ReadInt16(pcm)
+	assert.Nil(t, err)
+	for i, sample := range pcm {
+		if samples[i] > 0 {
+			assert.Equal(t, int16(16384), sample)
+		} else {
+			assert.Equal(t, int16(-16384), sample)
+		}
+	}
+}
+
+func TestGeneratorWrap(t *testing.T) {
+	t.Parallel()
+
+	g, err := NewGenerator(Frame{Timecode: mustParse(timecode.R2997DF, "23:59:59;29")}, 48000, 1)
+	assert.Nil(t, err)
+
+	_, err = g.ReadInt16(make([]int16, 1602))
+	assert.Nil(t, err)
+	assert.Equal(t, "00:00:00;00", g.Frame().Timecode.String())
+
+	// the Generator wraps like a hardware generator whatever the Wrap mode of the Rate is
+	for _, wrap := range []timecode.Wrap{timecode.WrapNone, timecode.Wrap24Hour, timecode.WrapError} {
+		rate := timecode.R25.WithWrap(wrap)
+		g, err := NewGenerator(Frame{Timecode: mustParse(rate, "23:59:59:24")}, 48000, 1)
+		assert.Nil(t, err)
+
+		_, err = g.ReadFloat32(make([]float32, 1920*2))
+		assert.Nil(t, err)
+		assert.Equal(t, "00:00:00:01", g.Frame().Timecode.String())
+		assert.Equal(t, rate, g.Frame().Timecode.Rate())
+	}
+}
+
+func TestGeneratorErrors(t *testing.T) {
+	t.Parallel()
+
+	start := Frame{Timecode: timecode.FromFrames(timecode.R30, 0)}
+
+	_, err := NewGenerator(start, 48000, 0)
+	assert.NotNil(t, err)
+
+	_, err = NewGenerator(start, 48000, 1.5)
+	assert.NotNil(t, err)
+
+	_, err = NewGenerator(start, 4000, 1)
+	assert.NotNil(t, err)
+
+	_, err = NewGenerator(Frame{Timecode: timecode.FromFrames(timecode.R60, 0)}, 48000, 1)
+	assert.True(t, errors.Is(err, ErrUnsupportedRate))
+
+	_, err = NewGenerator(Frame{Timecode: timecode.FromFrames(timecode.R30, -1)}, 48000, 1)
+	assert.NotNil(t, err)
+}
+
+func TestWriteWAV(t *testing.T) {
+	t.Parallel()
+
+	g, err := NewGenerator(Frame{Timecode: mustParse(timecode.R2997DF, "01:00:00;00")}, 48000, 0.25)
+	assert.Nil(t, err)
+
+	// 5 frames at 29.97 are exactly 8008 samples
+	var buf bytes.Buffer
+	assert.Nil(t, g.WriteWAV(&buf, 5))
+	assert.Equal(t, 44+8008*2, buf.Len())
+	assert.Equal(t, "01:00:00;05", g.Frame().Timecode.String())
+
+	f, err := bwf.Read(bytes.NewReader(buf.Bytes()))
+	assert.Nil(t, err)
+	assert.Equal(t, int64(48000), f.SampleRate)
+
+	pcm := make([]int16, 8008)
+	assert.Nil(t, binary.Read(bytes.NewReader(buf.Bytes()[44:]), binary.LittleEndian, pcm))
+	assert.Equal(t, int16(8192), pcm[0])
+
+	// continuing mid frame only finishes the current frame
+	_, err = g.ReadInt16(make([]int16, 100))
+	assert.Nil(t, err)
+
+	buf.Reset()
+	assert.Nil(t, g.WriteWAV(&buf, 1))
+	assert.Equal(t, 44+(1602-100)*2, buf.Len())
+	assert.Equal(t, "01:00:00;06", g.Frame().Timecode.String())
+
+	assert.NotNil(t, g.WriteWAV(&buf, -1))
+}
@@ -0,0 +1,267 @@
+// Package ltc encodes and decodes SMPTE 12M linear timecode (LTC). Each frame of timecode is carried by an
+// 80 bit codeword holding the BCD digits of the label, the drop frame and color frame flags, 32 user bits,
+// the binary group flags, a polarity correction bit and a 16 bit sync word. LTC carries rates up to 30
+// fps.
+package ltc
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	timecode "github.com/agorman/go-timecode/v2"
+)
+
+// WordBits is the number of bits in an LTC codeword.
+const WordBits = 80
+
+// syncWord is the sync word in bits 64 to 79 in the order the bits are sent. It's 0011111111111101 which
+// can't appear in the rest of the codeword and tells the direction of play.
+var syncWord = [2]byte{0xfc, 0xbf}
+
+// userBitsStart is the first bit of each of the 8 binary groups holding the user bits.
+var userBitsStart = [8]int{4, 12, 20, 28, 36, 44, 52, 60}
+
+const (
+	bitDropFrame  = 10
+	bitColorFrame = 11
+)
+
+var (
+	// ErrNoSync is returned when decoding a codeword that doesn't end in the sync word.
+	ErrNoSync = errors.New("ltc codeword has no sync word")
+
+	// ErrUnsupportedRate is returned for rates LTC can't carry which are rates over 30 fps.
+	ErrUnsupportedRate = errors.New("rate is not supported by ltc")
+)
+
+// Word is an LTC codeword. Bit 0 is sent first and is the least significant bit of the first byte.
+type Word [WordBits / 8]byte
+
+// Bit returns bit i of the codeword where bit 0 is sent first.
+func (w Word) Bit(i int) bool {
+	return w[i/8]&(1<<(i%8)) != 0
+}
+
+// setBit sets bit i of the codeword to value.
+func (w *Word) setBit(i int, value bool) {
+	if value {
+		w[i/8] |= 1 << (i % 8)
+	} else {
+		w[i/8] &^= 1 << (i % 8)
+	}
+}
+
+// bits returns the count bits starting at bit start as a number with the first bit as the least
+// significant bit.
+func (w Word) bits(start, count int) uint8 {
+	var value uint8
+	for i := 0; i < count; i++ {
+		if w.Bit(start + i) {
+			value |= 1 << i
+		}
+	}
+	return value
+}
+
+// setBits sets the count bits starting at bit start to value with the least significant bit first.
+func (w *Word) setBits(start, count int, value uint8) {
+	for i := 0; i < count; i++ {
+		w.setBit(start+i, value&(1<<i) != 0)
+	}
+}
+
+// BinaryGroupFlags are the binary group flags of an LTC codeword. BGF0 and BGF2 describe the contents of
+// the user bits and BGF1 is set when the timecode is locked to an external clock.
+type BinaryGroupFlags uint8
+
+const (
+	// BGF0 is set when the user bits hold 8 bit characters such as ISO 646.
+	BGF0 BinaryGroupFlags = 1 << iota
+
+	// BGF1 is set when the timecode is locked to an external clock.
+	BGF1
+
+	// BGF2 is set with BGF0 clear when the user bits hold a date and time zone as defined by SMPTE 309M.
+	BGF2
+)
+
+// UserBits are the 32 user bits of an LTC codeword. The 8 binary groups of 4 bits are held with binary
+// group 1 in the least significant bits so formatting UserBits as %08X shows binary group 8 first.
+type UserBits uint32
+
+// Group returns binary group n of the user bits where n is between 1 and 8.
+func (ub UserBits) Group(n int) uint8 {
+	return uint8(ub>>((n-1)*4)) & 0xf
+}
+
+// WithGroup returns a copy of the user bits with binary group n, between 1 and 8, set to the low 4 bits of
+// value.
+func (ub UserBits) WithGroup(n int, value uint8) UserBits {
+	shift := (n - 1) * 4
+	return ub&^(0xf<<shift) | UserBits(value&0xf)<<shift
+}
+
+// Frame is the contents of an LTC codeword.
+type Frame struct {
+	// Timecode is the timecode of the frame. The drop frame flag is taken from its Rate.
+	Timecode timecode.Timecode
+
+	// UserBits are the user bits of the frame.
+	UserBits UserBits
+
+	// ColorFrame is set when the timecode is locked to the color framing sequence of the video.
+	ColorFrame bool
+
+	// Flags are the binary group flags of the frame.
+	Flags BinaryGroupFlags
+}
+
+// flagBits are the positions of BGF0, BGF1, BGF2 and the polarity correction bit which move for 25 fps.
+type flagBits struct {
+	bgf      [3]int
+	polarity int
+}
+
+var (
+	flagBits25 = flagBits{bgf: [3]int{27, 58, 43}, polarity: 59}
+	flagBits30 = flagBits{bgf: [3]int{43, 58, 59}, polarity: 27}
+)
+
+// Encode returns the LTC codeword of f. The polarity correction bit is set so the codeword has an even
+// number of 0 bits which keeps the signal polarity the same at the start of every codeword. An
+// ErrUnsupportedRate error is returned if the Rate is over 30 fps and an error is returned if the
+// Timecode is negative or 24 hours or more.
+func Encode(f Frame) (Word, error) {
+	var w Word
+
+	tc := f.Timecode
+	layout, err := layoutFor(tc.Rate())
+	if err != nil {
+		return w, err
+	}
+
+	if tc.Negative() {
+		return w, fmt.Errorf("ltc can't encode a negative timecode: %s", tc)
+	}
+	if tc.Hour() > 23 {
+		return w, fmt.Errorf("ltc can't encode a timecode of 24 hours or more: %s", tc)
+	}
+
+	digits := []struct {
+		value uint64
+		start int
+		tens  int
+	}{
+		{tc.Frame(), 0, 2},
+		{tc.Second(), 16, 3},
+		{tc.Minute(), 32, 3},
+		{tc.Hour(), 48, 2},
+	}
+	for _, digit := range digits {
+		w.setBits(digit.start, 4, uint8(digit.value%10))
+		w.setBits(digit.start+8, digit.tens, uint8(digit.value/10))
+	}
+
+	for i, start := range userBitsStart {
+		w.setBits(start, 4, f.UserBits.Group(i+1))
+	}
+
+	w.setBit(bitDropFrame, tc.Rate().DropFrame())
+	w.setBit(bitColorFrame, f.ColorFrame)
+	for i, bit := range layout.bgf {
+		w.setBit(bit, f.Flags&(1<<i) != 0)
+	}
+
+	w[8], w[9] = syncWord[0], syncWord[1]
+
+	// the polarity bit is 0 so far, set it if there's an odd number of 0 bits
+	if zeros(w)%2 == 1 {
+		w.setBit(layout.polarity, true)
+	}
+
+	return w, nil
+}
+
+// Decode returns the contents of the LTC codeword w using rate. An ErrNoSync error is returned if w
+// doesn't end in the sync word, an ErrUnsupportedRate error is returned if rate is over 30 fps and an
+// error is returned if the digits aren't a valid label for rate or the drop frame flag doesn't match rate.
+func Decode(w Word, rate timecode.Rate) (Frame, error) {
+	var f Frame
+
+	if w[8] != syncWord[0] || w[9] != syncWord[1] {
+		return f, ErrNoSync
+	}
+
+	layout, err := layoutFor(rate)
+	if err != nil {
+		return f, err
+	}
+
+	if w.Bit(bitDropFrame) != rate.DropFrame() {
+		return f, fmt.Errorf("ltc drop frame flag doesn't match rate: %s", rate)
+	}
+
+	var parts [4]uint8
+	for i, digit := range []struct {
+		start int
+		tens  int
+	}{
+		{48, 2},
+		{32, 3},
+		{16, 3},
+		{0, 2},
+	} {
+		units := w.bits(digit.start, 4)
+		if units > 9 {
+			return f, fmt.Errorf("invalid ltc BCD digit: %d", units)
+		}
+		parts[i] = w.bits(digit.start+8, digit.tens)*10 + units
+	}
+
+	if parts[0] > 23 {
+		return f, fmt.Errorf("invalid ltc hours: %d", parts[0])
+	}
+
+	f.Timecode, err = timecode.Parse(rate, fmt.Sprintf("%02d:%02d:%02d:%02d", parts[0], parts[1], parts[2], parts[3]))
+	if err != nil {
+		return f, err
+	}
+
+	for i, start := range userBitsStart {
+		f.UserBits = f.UserBits.WithGroup(i+1, w.bits(start, 4))
+	}
+
+	f.ColorFrame = w.Bit(bitColorFrame)
+	for i, bit := range layout.bgf {
+		if w.Bit(bit) {
+			f.Flags |= 1 << i
+		}
+	}
+
+	return f, nil
+}
+
+// layoutFor returns the positions of the flag bits for rate. An ErrUnsupportedRate error is returned if
+// rate is over 30 fps.
+func layoutFor(rate timecode.Rate) (flagBits, error) {
+	switch timeBase := math.Round(rate.FPS()); {
+	case timeBase < 1 || timeBase > 30:
+		return flagBits{}, fmt.Errorf("%w: %s", ErrUnsupportedRate, rate)
+	case timeBase == 25:
+		return flagBits25, nil
+	default:
+		return flagBits30, nil
+	}
+}
+
+// zeros returns the number of 0 bits in w.
+func zeros(w Word) int {
+	count := 0
+	for i := 0; i < WordBits; i++ {
+		if !w.Bit(i) {
+			count++
+		}
+	}
+	return count
+}
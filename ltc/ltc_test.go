@@ -0,0 +1,144 @@
+package ltc
+
+import (
+	"errors"
+	"testing"
+
+	timecode "github.com/agorman/go-timecode/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+// mustParse returns the Timecode of s at rate or panics.
+func mustParse(rate timecode.Rate, s string) timecode.Timecode {
+	tc, err := timecode.Parse(rate, s)
+	if err != nil {
+		panic(err)
+	}
+	return tc
+}
+
+func TestEncode(t *testing.T) {
+	t.Parallel()
+
+	// only the sync word is set so the polarity bit is needed for an even number of 0 bits
+	w, err := Encode(Frame{Timecode: timecode.FromFrames(timecode.R30, 0)})
+	assert.Nil(t, err)
+	assert.Equal(t, Word{0, 0, 0, 0x08, 0, 0, 0, 0, 0xfc, 0xbf}, w)
+
+	w, err = Encode(Frame{Timecode: timecode.FromFrames(timecode.R25, 0)})
+	assert.Nil(t, err)
+	assert.Equal(t, Word{0, 0, 0, 0, 0, 0, 0, 0x08, 0xfc, 0xbf}, w)
+
+	w, err = Encode(Frame{
+		Timecode:   mustParse(timecode.R2997DF, "23:59:58;29"),
+		UserBits:   0x87654321,
+		ColorFrame: true,
+		Flags:      BGF1,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, uint8(9), w.bits(0, 4))
+	assert.Equal(t, uint8(2), w.bits(8, 2))
+	assert.Equal(t, uint8(8), w.bits(16, 4))
+	assert.Equal(t, uint8(5), w.bits(24, 3))
+	assert.Equal(t, uint8(9), w.bits(32, 4))
+	assert.Equal(t, uint8(5), w.bits(40, 3))
+	assert.Equal(t, uint8(3), w.bits(48, 4))
+	assert.Equal(t, uint8(2), w.bits(56, 2))
+	assert.True(t, w.Bit(bitDropFrame))
+	assert.True(t, w.Bit(bitColorFrame))
+	assert.True(t, w.Bit(58))
+	for i, start := range userBitsStart {
+		assert.Equal(t, uint8(i+1), w.bits(start, 4))
+	}
+	assert.Equal(t, 0, zeros(w)%2)
+
+	_, err = Encode(Frame{Timecode: timecode.FromFrames(timecode.R25, -1)})
+	assert.NotNil(t, err)
+
+	_, err = Encode(Frame{Timecode: mustParse(timecode.R25, "24:00:00:00")})
+	assert.NotNil(t, err)
+
+	_, err = Encode(Frame{Timecode: timecode.FromFrames(timecode.R50, 0)})
+	assert.True(t, errors.Is(err, ErrUnsupportedRate))
+}
+
+func TestDecode(t *testing.T) {
+	t.Parallel()
+
+	rates := []timecode.Rate{timecode.R24, timecode.R2398, timecode.R25, timecode.R2997, timecode.R2997DF, timecode.R30}
+	for _, rate := range rates {
+		for _, frames := range []int64{0, 1, 59, 1799, 17982, 107892, rate.FramesPerDay() - 1} {
+			for _, flags := range []BinaryGroupFlags{0, BGF0, BGF1, BGF2, BGF0 | BGF1 | BGF2} {
+				f := Frame{
+					Timecode:   timecode.FromFrames(rate, frames),
+					UserBits:   UserBits(frames * 2654435761),
+					ColorFrame: frames%2 == 1,
+					Flags:      flags,
+				}
+
+				w, err := Encode(f)
+				assert.Nil(t, err)
+				assert.Equal(t, 0, zeros(w)%2)
+
+				back, err := Decode(w, rate)
+				assert.Nil(t, err)
+				assert.Equal(t, f, back, "%s %s", rate, f.Timecode)
+			}
+		}
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	t.Parallel()
+
+	w, err := Encode(Frame{Timecode: mustParse(timecode.R2997DF, "00:00:59;29")})
+	assert.Nil(t, err)
+
+	_, err = Decode(w, timecode.R2997)
+	assert.NotNil(t, err)
+
+	_, err = Decode(w, timecode.R60)
+	assert.True(t, errors.Is(err, ErrUnsupportedRate))
+
+	broken := w
+	broken.setBit(79, false)
+	_, err = Decode(broken, timecode.R2997DF)
+	assert.True(t, errors.Is(err, ErrNoSync))
+
+	// 00:01:00;00 is skipped by drop frame
+	dropped := w
+	dropped.setBits(0, 4, 0)
+	dropped.setBits(8, 2, 0)
+	dropped.setBits(16, 4, 0)
+	dropped.setBits(24, 3, 0)
+	dropped.setBits(32, 4, 1)
+	_, err = Decode(dropped, timecode.R2997DF)
+	assert.True(t, errors.Is(err, timecode.ErrDroppedFrame))
+
+	invalid := w
+	invalid.setBits(16, 4, 0xc)
+	_, err = Decode(invalid, timecode.R2997DF)
+	assert.NotNil(t, err)
+
+	invalid = w
+	invalid.setBits(24, 3, 7)
+	_, err = Decode(invalid, timecode.R2997DF)
+	assert.NotNil(t, err)
+
+	invalid = w
+	invalid.setBits(56, 2, 3)
+	_, err = Decode(invalid, timecode.R2997DF)
+	assert.NotNil(t, err)
+}
+
+func TestUserBits(t *testing.T) {
+	t.Parallel()
+
+	ub := UserBits(0x87654321)
+	for n := 1; n <= 8; n++ {
+		assert.Equal(t, uint8(n), ub.Group(n))
+	}
+
+	ub = ub.WithGroup(3, 0xfa)
+	assert.Equal(t, UserBits(0x87654a21), ub)
+}
@@ -0,0 +1,335 @@
+package timecode
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+const (
+	// binaryVersion is the first byte of the binary encoding of a Rate or Timecode. It's increased when the
+	// layout of the encoding changes.
+	binaryVersion = 1
+
+	// rateBinaryLen is the length of the binary encoding of a Rate. This is the version, num, den, flags,
+	// wrap mode and sub-frames.
+	rateBinaryLen = 1 + 8 + 8 + 1 + 1 + 8
+
+	// timecodeBinaryLen is the length of the binary encoding of a Timecode. This is the encoding of its
+	// Rate followed by the frames, field and sub-frame.
+	timecodeBinaryLen = rateBinaryLen + 8 + 1 + 8
+)
+
+const (
+	// rateFlagDropFrame is set in the flags of a Rate using drop frame encoding.
+	rateFlagDropFrame = 1 << iota
+
+	// rateFlagInterlaced is set in the flags of a Rate using ScanModeInterlaced.
+	rateFlagInterlaced
+)
+
+// errNoRate is returned when marshaling a Timecode or Rate that is the zero value and has no frame rate.
+var errNoRate = errors.New("timecode has no rate")
+
+// timecodeJSON is the JSON object form of a Timecode.
+type timecodeJSON struct {
+	Timecode string `json:"timecode"`
+	Rate     Rate   `json:"rate"`
+}
+
+// MarshalText implements encoding.TextMarshaler. The Rate is encoded the same as String such as
+// 30000/1001 DF or 30000/1001 DF wrap 24h. The zero Rate is encoded as empty text so a struct with an
+// unset Rate can still be marshaled.
+func (r Rate) MarshalText() ([]byte, error) {
+	if r.den == 0 {
+		return []byte{}, nil
+	}
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. The text is parsed with ParseRate so any form it
+// accepts such as 29.97DF or 50i may be used. Empty text results in the zero Rate.
+func (r *Rate) UnmarshalText(text []byte) error {
+	if len(bytes.TrimSpace(text)) == 0 {
+		*r = Rate{}
+		return nil
+	}
+
+	rate, err := ParseRate(string(text), false)
+	if err != nil {
+		return err
+	}
+
+	*r = rate
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. The encoding includes the Wrap mode.
+func (r Rate) MarshalBinary() ([]byte, error) {
+	if r.den == 0 {
+		return nil, errNoRate
+	}
+
+	buf := make([]byte, rateBinaryLen)
+	r.putBinary(buf)
+	return buf, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (r *Rate) UnmarshalBinary(data []byte) error {
+	if len(data) != rateBinaryLen {
+		return fmt.Errorf("invalid rate binary length: %d", len(data))
+	}
+
+	rate, err := rateFromBinary(data)
+	if err != nil {
+		return err
+	}
+
+	*r = rate
+	return nil
+}
+
+// putBinary writes the binary encoding of the Rate to the first rateBinaryLen bytes of buf.
+func (r Rate) putBinary(buf []byte) {
+	buf[0] = binaryVersion
+	binary.BigEndian.PutUint64(buf[1:], uint64(r.num))
+	binary.BigEndian.PutUint64(buf[9:], uint64(r.den))
+	if r.dropFrame {
+		buf[17] |= rateFlagDropFrame
+	}
+	if r.scan == ScanModeInterlaced {
+		buf[17] |= rateFlagInterlaced
+	}
+	buf[18] = byte(r.wrap)
+	binary.BigEndian.PutUint64(buf[19:], uint64(r.subFrames))
+}
+
+// rateFromBinary reads and validates a Rate from the first rateBinaryLen bytes of data.
+func rateFromBinary(data []byte) (Rate, error) {
+	if data[0] != binaryVersion {
+		return Rate{}, fmt.Errorf("unsupported rate binary version: %d", data[0])
+	}
+
+	num := int64(binary.BigEndian.Uint64(data[1:]))
+	den := int64(binary.BigEndian.Uint64(data[9:]))
+	if den < 1 || num < den {
+		return Rate{}, fmt.Errorf("rate must be at least 1 fps but got: %d/%d", num, den)
+	}
+
+	flags := data[17]
+	if flags&^(rateFlagDropFrame|rateFlagInterlaced) != 0 {
+		return Rate{}, fmt.Errorf("invalid rate flags: %d", flags)
+	}
+
+	wrap := Wrap(data[18])
+	if wrap != WrapNone && wrap != Wrap24Hour && wrap != WrapError {
+		return Rate{}, fmt.Errorf("invalid rate wrap mode: %d", wrap)
+	}
+
+	subFrames := int64(binary.BigEndian.Uint64(data[19:]))
+	if subFrames < 0 || subFrames > math.MaxInt32 {
+		return Rate{}, fmt.Errorf("invalid rate sub-frames: %d", subFrames)
+	}
+
+	if flags&rateFlagInterlaced != 0 && subFrames > 0 {
+		return Rate{}, fmt.Errorf("%w: %d sub-frames", errInterlacedSubFrames, subFrames)
+	}
+
+	rate := newRate(num, den, flags&rateFlagDropFrame != 0).WithWrap(wrap).WithSubFrames(int(subFrames))
+	if flags&rateFlagInterlaced != 0 {
+		rate.scan = ScanModeInterlaced
+	}
+	return rate, validateDropFrame(rate)
+}
+
+// MarshalJSON implements json.Marshaler. The Timecode is encoded as an object holding its label and Rate
+// such as {"timecode":"01:00:00;00","rate":"30000/1001 DF"}. The zero Timecode is encoded as null. Use
+// CompactTimecode to encode the Timecode as a single string instead.
+func (tc Timecode) MarshalJSON() ([]byte, error) {
+	if tc.rate.den == 0 {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(timecodeJSON{
+		Timecode: tc.String(),
+		Rate:     tc.rate,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Both the object form written by MarshalJSON and the string
+// form written by MarshalText are accepted and drop frame encoding is inferred from the label the same as
+// UnmarshalText. The label is parsed with Parse so labels skipped by drop frame encoding are rejected. A
+// JSON null leaves the Timecode unchanged.
+func (tc *Timecode) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		return tc.UnmarshalText([]byte(s))
+	}
+
+	var obj struct {
+		Timecode *string `json:"timecode"`
+		Rate     *string `json:"rate"`
+	}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+	if obj.Timecode == nil || obj.Rate == nil {
+		return fmt.Errorf("timecode json must have a timecode and rate: %s", data)
+	}
+
+	rate, err := parseLabelRate(*obj.Timecode, *obj.Rate)
+	if err != nil {
+		return err
+	}
+
+	parsed, err := Parse(rate, *obj.Timecode)
+	if err != nil {
+		return err
+	}
+
+	*tc = parsed
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler. The Timecode is encoded as its label and Rate separated
+// by an @ such as 01:00:00;00@30000/1001 DF. The Rate is encoded the same as Rate.MarshalText so it
+// includes the Wrap mode.
+func (tc Timecode) MarshalText() ([]byte, error) {
+	if tc.rate.den == 0 {
+		return nil, errNoRate
+	}
+	return []byte(tc.String() + "@" + tc.rate.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. The text must be in the form label@rate where the
+// label is parsed with Parse and the rate with ParseRate. When the rate has no DF or NDF suffix drop frame
+// encoding is taken from the label, so 01:00:00;00@30000/1001 is drop frame and 01:00:00:00@30000/1001
+// isn't.
+func (tc *Timecode) UnmarshalText(text []byte) error {
+	s := string(text)
+
+	i := strings.LastIndex(s, "@")
+	if i < 0 {
+		return fmt.Errorf("timecode text must be in the form label@rate: %s", s)
+	}
+	label := strings.TrimSpace(s[:i])
+
+	rate, err := parseLabelRate(label, s[i+1:])
+	if err != nil {
+		return err
+	}
+
+	parsed, err := Parse(rate, label)
+	if err != nil {
+		return err
+	}
+
+	*tc = parsed
+	return nil
+}
+
+// parseLabelRate parses the rate stored alongside label with ParseRate. When the rate has no DF or NDF
+// suffix drop frame encoding is taken from the frame separator of label.
+func parseLabelRate(label, s string) (Rate, error) {
+	rate, err := ParseRate(s, false)
+	if err != nil {
+		return rate, err
+	}
+
+	matches := rateRegExp.FindStringSubmatch(strings.TrimSpace(s))
+	labelMatches := timecodeRegExp.FindStringSubmatch(label)
+	if matches[5] == "" && labelMatches != nil && (labelMatches[5] == ";" || labelMatches[5] == ",") {
+		df := rate
+		df.dropFrame = true
+		if validateDropFrame(df) == nil {
+			rate = df
+		}
+	}
+
+	return rate, nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. The encoding holds the binary encoding of the Rate,
+// including its Wrap mode, followed by the frames, field and sub-frame.
+func (tc Timecode) MarshalBinary() ([]byte, error) {
+	if tc.rate.den == 0 {
+		return nil, errNoRate
+	}
+
+	buf := make([]byte, timecodeBinaryLen)
+	tc.rate.putBinary(buf)
+	binary.BigEndian.PutUint64(buf[rateBinaryLen:], uint64(tc.frames))
+	if tc.secondField {
+		buf[rateBinaryLen+8] = 1
+	}
+	binary.BigEndian.PutUint64(buf[rateBinaryLen+9:], uint64(tc.subFrame))
+	return buf, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. The frames are checked against the Wrap mode of
+// the Rate.
+func (tc *Timecode) UnmarshalBinary(data []byte) error {
+	if len(data) != timecodeBinaryLen {
+		return fmt.Errorf("invalid timecode binary length: %d", len(data))
+	}
+
+	rate, err := rateFromBinary(data)
+	if err != nil {
+		return err
+	}
+
+	frames := int64(binary.BigEndian.Uint64(data[rateBinaryLen:]))
+	if day := rate.FramesPerDay(); rate.wrap != WrapNone && (frames < 0 || frames >= day) {
+		return fmt.Errorf("frames must be between 0 and %d for %s got: %d", day-1, rate, frames)
+	}
+
+	field := data[rateBinaryLen+8]
+	if field > 1 || (field == 1 && rate.scan != ScanModeInterlaced) {
+		return fmt.Errorf("invalid timecode field: %d", field)
+	}
+
+	subFrame := int64(binary.BigEndian.Uint64(data[rateBinaryLen+9:]))
+	if subFrame < 0 || (subFrame > 0 && subFrame >= rate.subFrames) {
+		return fmt.Errorf("invalid timecode sub-frame: %d", subFrame)
+	}
+
+	*tc = Timecode{
+		rate:        rate,
+		frames:      frames,
+		secondField: field == 1,
+		subFrame:    subFrame,
+	}
+	return nil
+}
+
+// CompactTimecode is a Timecode that is encoded as a single JSON string such as
+// "01:00:00;00@30000/1001 DF" rather than an object. It's useful for APIs that store many timecodes.
+type CompactTimecode struct {
+	Timecode
+}
+
+// MarshalJSON implements json.Marshaler and encodes the Timecode as the JSON string form of MarshalText.
+func (c CompactTimecode) MarshalJSON() ([]byte, error) {
+	if c.rate.den == 0 {
+		return []byte("null"), nil
+	}
+
+	text, err := c.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
@@ -0,0 +1,226 @@
+package timecode
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateMarshalText(t *testing.T) {
+	t.Parallel()
+
+	for _, rate := range []Rate{R2997, R2997DF, R30, R5994, R5994DF, R60, R25, R50, R2398, R24, R120, R240} {
+		text, err := rate.MarshalText()
+		assert.Nil(t, err)
+		assert.Equal(t, rate.String(), string(text))
+
+		var back Rate
+		assert.Nil(t, back.UnmarshalText(text))
+		assert.Equal(t, rate, back)
+	}
+
+	data, err := json.Marshal(R2997DF)
+	assert.Nil(t, err)
+	assert.Equal(t, `"30000/1001 DF"`, string(data))
+
+	var rate Rate
+	assert.Nil(t, json.Unmarshal([]byte(`"59.94i DF"`), &rate))
+	assert.Equal(t, R2997DF.WithScanMode(ScanModeInterlaced), rate)
+
+	assert.NotNil(t, json.Unmarshal([]byte(`"25 DF"`), &rate))
+	assert.NotNil(t, json.Unmarshal([]byte(`"fast"`), &rate))
+
+	text, err := Rate{}.MarshalText()
+	assert.Nil(t, err)
+	assert.Equal(t, "", string(text))
+
+	rate = R25
+	assert.Nil(t, rate.UnmarshalText(text))
+	assert.Equal(t, Rate{}, rate)
+
+	type settings struct {
+		Rate Rate `json:"rate"`
+	}
+
+	data, err = json.Marshal(settings{})
+	assert.Nil(t, err)
+	assert.Equal(t, `{"rate":""}`, string(data))
+
+	back := settings{Rate: R25}
+	assert.Nil(t, json.Unmarshal(data, &back))
+	assert.Equal(t, settings{}, back)
+}
+
+func TestRateMarshalBinary(t *testing.T) {
+	t.Parallel()
+
+	for _, rate := range []Rate{R2997DF, R25, R5994.WithWrap(Wrap24Hour), R24.WithWrap(WrapError)} {
+		data, err := rate.MarshalBinary()
+		assert.Nil(t, err)
+
+		var back Rate
+		assert.Nil(t, back.UnmarshalBinary(data))
+		assert.Equal(t, rate, back)
+	}
+
+	data, err := R25.MarshalBinary()
+	assert.Nil(t, err)
+
+	var rate Rate
+	assert.NotNil(t, rate.UnmarshalBinary(data[:len(data)-1]))
+
+	bad := append([]byte(nil), data...)
+	bad[0] = 9
+	assert.NotNil(t, rate.UnmarshalBinary(bad))
+
+	bad = append([]byte(nil), data...)
+	bad[17] = 1
+	assert.NotNil(t, rate.UnmarshalBinary(bad))
+
+	bad = append([]byte(nil), data...)
+	bad[18] = 7
+	assert.NotNil(t, rate.UnmarshalBinary(bad))
+
+	bad = append([]byte(nil), data...)
+	bad[16] = 0
+	assert.NotNil(t, rate.UnmarshalBinary(bad))
+}
+
+func TestTimecodeMarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	tc, err := Parse(R2997DF, "01:00:00;00")
+	assert.Nil(t, err)
+
+	data, err := json.Marshal(tc)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"timecode":"01:00:00;00","rate":"30000/1001 DF"}`, string(data))
+
+	var back Timecode
+	assert.Nil(t, json.Unmarshal(data, &back))
+	assert.Equal(t, tc, back)
+
+	back = Timecode{}
+	assert.Nil(t, json.Unmarshal([]byte(`"01:00:00;00@29.97DF"`), &back))
+	assert.Equal(t, tc, back)
+
+	// drop frame is taken from the label in both forms when the rate has no DF or NDF suffix
+	for _, s := range []string{`{"timecode":"01:00:00;00","rate":"30000/1001"}`, `"01:00:00;00@30000/1001"`} {
+		back = Timecode{}
+		assert.Nil(t, json.Unmarshal([]byte(s), &back), s)
+		assert.Equal(t, tc, back, s)
+	}
+
+	back = Timecode{}
+	assert.Nil(t, json.Unmarshal([]byte(`{"timecode":"01:00:00;00","rate":"30000/1001 NDF"}`), &back))
+	assert.Equal(t, R2997, back.Rate())
+
+	interlaced, err := FromFields(R2997DF.WithScanMode(ScanModeInterlaced), 5)
+	assert.Nil(t, err)
+	back = Timecode{}
+	assert.Nil(t, json.Unmarshal([]byte(`{"timecode":"00:00:00,02","rate":"60000/1001i"}`), &back))
+	assert.Equal(t, interlaced, back)
+
+	neg, err := Parse(R25, "-00:00:01:12")
+	assert.Nil(t, err)
+	data, err = json.Marshal(neg)
+	assert.Nil(t, err)
+	back = Timecode{}
+	assert.Nil(t, json.Unmarshal(data, &back))
+	assert.Equal(t, neg, back)
+
+	data, err = json.Marshal(Timecode{})
+	assert.Nil(t, err)
+	assert.Equal(t, "null", string(data))
+
+	back = tc
+	assert.Nil(t, json.Unmarshal([]byte("null"), &back))
+	assert.Equal(t, tc, back)
+
+	for _, s := range []string{
+		`{"timecode":"01:00:00;00"}`,
+		`{"rate":"25"}`,
+		`{"timecode":"00:01:00;00","rate":"30000/1001 DF"}`,
+		`{"timecode":"01:00:00:00","rate":"25 DF"}`,
+		`"01:00:00:00"`,
+		`[]`,
+	} {
+		assert.NotNil(t, json.Unmarshal([]byte(s), &back), s)
+	}
+
+	type clip struct {
+		In  Timecode        `json:"in"`
+		Out CompactTimecode `json:"out"`
+	}
+
+	out, err := Parse(R25, "10:00:10:00")
+	assert.Nil(t, err)
+
+	data, err = json.Marshal(clip{In: FromFrames(R25, 900000), Out: CompactTimecode{out}})
+	assert.Nil(t, err)
+	assert.Equal(t, `{"in":{"timecode":"10:00:00:00","rate":"25/1"},"out":"10:00:10:00@25/1"}`, string(data))
+
+	var c clip
+	assert.Nil(t, json.Unmarshal(data, &c))
+	assert.Equal(t, FromFrames(R25, 900000), c.In)
+	assert.Equal(t, out, c.Out.Timecode)
+}
+
+func TestTimecodeMarshalText(t *testing.T) {
+	t.Parallel()
+
+	tc, err := Parse(R2398, "00:59:59:23")
+	assert.Nil(t, err)
+
+	text, err := tc.MarshalText()
+	assert.Nil(t, err)
+	assert.Equal(t, "00:59:59:23@24000/1001", string(text))
+
+	var back Timecode
+	assert.Nil(t, back.UnmarshalText(text))
+	assert.Equal(t, tc, back)
+
+	m := map[Timecode]string{tc: "marker"}
+	data, err := json.Marshal(m)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"00:59:59:23@24000/1001":"marker"}`, string(data))
+
+	assert.NotNil(t, back.UnmarshalText([]byte("00:59:59:23")))
+	assert.NotNil(t, back.UnmarshalText([]byte("00:59:59:23@")))
+	assert.NotNil(t, back.UnmarshalText([]byte("@24")))
+
+	_, err = Timecode{}.MarshalText()
+	assert.NotNil(t, err)
+}
+
+func TestTimecodeMarshalBinary(t *testing.T) {
+	t.Parallel()
+
+	tcs := []Timecode{
+		FromFrames(R2997DF, 107892),
+		FromFrames(R25, -37),
+		FromFrames(R24.WithWrap(Wrap24Hour), 2073599),
+	}
+
+	for _, tc := range tcs {
+		data, err := tc.MarshalBinary()
+		assert.Nil(t, err)
+		assert.Len(t, data, timecodeBinaryLen)
+
+		var back Timecode
+		assert.Nil(t, back.UnmarshalBinary(data))
+		assert.Equal(t, tc, back)
+	}
+
+	data, err := FromFrames(R24.WithWrap(WrapError), 0).MarshalBinary()
+	assert.Nil(t, err)
+	data[rateBinaryLen+5] = 0xff
+
+	var back Timecode
+	assert.NotNil(t, back.UnmarshalBinary(data))
+	assert.NotNil(t, back.UnmarshalBinary(data[:rateBinaryLen]))
+
+	_, err = Timecode{}.MarshalBinary()
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,193 @@
+package timecode
+
+import (
+	"fmt"
+	"math"
+)
+
+var (
+	// Pulldown23 is standard 2:3 pulldown. The film frames A, B, C and D are shown for 2, 3, 2 and 3 fields
+	// so every 4 film frames become the 5 video frames AA, BB, BC, CD and DD.
+	Pulldown23 = Pulldown{name: "2:3", fields: [4]int64{2, 3, 2, 3}}
+
+	// Pulldown2332 is 2:3:3:2 advanced pulldown. The film frames A, B, C and D are shown for 2, 3, 3 and 2
+	// fields so every 4 film frames become the 5 video frames AA, BB, BC, CC and DD. Only the BC frame mixes
+	// film frames so it can be dropped to recover the film frames.
+	Pulldown2332 = Pulldown{name: "2:3:3:2", fields: [4]int64{2, 3, 3, 2}}
+)
+
+// cadenceLetters are the letters of the film frames in a pulldown cycle.
+const cadenceLetters = "ABCD"
+
+// Pulldown maps film frames such as 23.976 fps to video frames with 5/4 the frame rate such as 29.97 fps by
+// repeating fields in a cadence that repeats every 4 film frames and 5 video frames. Each video frame is
+// made of a first and second field and belongs to the film frame shown in its first field. The phase is the
+// position in the cadence of video frame 0 where 0 is the AA frame and 4 is the DD frame. Film frame 0 is
+// the film frame shown in the first field of video frame 0.
+type Pulldown struct {
+	name   string
+	fields [4]int64
+	phase  int64
+}
+
+// FieldPair holds the film frames shown in the first and second fields of a video frame.
+type FieldPair struct {
+	First  Timecode
+	Second Timecode
+}
+
+// Split returns true if the fields of the video frame come from different film frames such as the BC and
+// CD frames of 2:3 pulldown.
+func (fp FieldPair) Split() bool {
+	return fp.First.frames != fp.Second.frames
+}
+
+// WithPhase returns a copy of the Pulldown where video frame 0 is at position phase in the cadence. The
+// phase is taken modulo 5 so it's between 0 (AA) and 4 (DD).
+func (p Pulldown) WithPhase(phase int) Pulldown {
+	p.phase = floorMod(int64(phase), 5)
+	return p
+}
+
+// Phase returns the position in the cadence of video frame 0 where 0 is the AA frame.
+func (p Pulldown) Phase() int {
+	return int(p.phase)
+}
+
+// String returns the name of the Pulldown such as 2:3.
+func (p Pulldown) String() string {
+	return p.name
+}
+
+// ToVideo returns the first video frame of the film frame film using the video Rate. This is the first video
+// frame whose first field shows film so converting it back with ToFilm returns film. An error is returned
+// if video isn't 5/4 of the Rate of film.
+func (p Pulldown) ToVideo(film Timecode, video Rate) (Timecode, error) {
+	tc := Timecode{
+		rate: video,
+	}
+
+	if err := checkPulldownRates(film.rate, video); err != nil {
+		return tc, err
+	}
+
+	if film.frames > math.MaxInt64/2 || film.frames < math.MinInt64/2 {
+		return tc, fmt.Errorf("%w: %s can not be converted to %s", ErrOverflow, film, video)
+	}
+
+	// the field the film frame starts on in the cadence starting at the A frame of cycle 0
+	filmFrame := film.frames + p.firstFilmFrame()
+	cycle := floorDiv(filmFrame, 4)
+	field := cycle*10 + p.fieldStart(filmFrame-cycle*4)
+
+	// round up to the first field of a video frame
+	frames, err := video.bound(floorDiv(field+1, 2) - p.phase)
+	if err != nil {
+		return tc, err
+	}
+
+	tc.frames = frames
+	return tc, nil
+}
+
+// ToFilm returns the film frame shown in the first field of the video frame video using the film Rate. An
+// error is returned if the Rate of video isn't 5/4 of film.
+func (p Pulldown) ToFilm(video Timecode, film Rate) (Timecode, error) {
+	fields, err := p.Fields(video, film)
+	return fields.First, err
+}
+
+// Fields returns the film frames shown in the first and second fields of the video frame video using the
+// film Rate. An error is returned if the Rate of video isn't 5/4 of film.
+func (p Pulldown) Fields(video Timecode, film Rate) (FieldPair, error) {
+	fp := FieldPair{
+		First:  Timecode{rate: film},
+		Second: Timecode{rate: film},
+	}
+
+	if err := checkPulldownRates(film, video.rate); err != nil {
+		return fp, err
+	}
+
+	if video.frames > math.MaxInt64/2-5 || video.frames < math.MinInt64/2 {
+		return fp, fmt.Errorf("%w: %s can not be converted to %s", ErrOverflow, video, film)
+	}
+
+	field := (video.frames + p.phase) * 2
+	for i, tc := range []*Timecode{&fp.First, &fp.Second} {
+		frames, err := film.bound(p.filmFrameOfField(field+int64(i)) - p.firstFilmFrame())
+		if err != nil {
+			return fp, err
+		}
+		tc.frames = frames
+	}
+
+	return fp, nil
+}
+
+// Letter returns the cadence letter A, B, C or D of the film frame film.
+func (p Pulldown) Letter(film Timecode) string {
+	i := floorMod(film.frames+p.firstFilmFrame(), 4)
+	return cadenceLetters[i : i+1]
+}
+
+// Cadence returns the letters of the film frames shown in the first and second fields of the video frame
+// video such as AA or BC.
+func (p Pulldown) Cadence(video Timecode) string {
+	field := floorMod(video.frames+p.phase, 5) * 2
+	first, second := p.filmFrameOfField(field), p.filmFrameOfField(field+1)
+	return cadenceLetters[first:first+1] + cadenceLetters[second:second+1]
+}
+
+// firstFilmFrame returns the position of film frame 0 in the cadence starting at the A frame of cycle 0.
+func (p Pulldown) firstFilmFrame() int64 {
+	return p.filmFrameOfField(p.phase * 2)
+}
+
+// filmFrameOfField returns the film frame shown in field in the cadence starting at the A frame of cycle 0.
+func (p Pulldown) filmFrameOfField(field int64) int64 {
+	cycle := floorDiv(field, 10)
+	remaining := field - cycle*10
+
+	letter := int64(0)
+	for letter < 3 && p.fieldStart(letter+1) <= remaining {
+		letter++
+	}
+
+	return cycle*4 + letter
+}
+
+// fieldStart returns the first field of the film frame with the letter index within a cycle.
+func (p Pulldown) fieldStart(letter int64) int64 {
+	var field int64
+	for i := int64(0); i < letter; i++ {
+		field += p.fields[i]
+	}
+	return field
+}
+
+// checkPulldownRates returns an error unless video is 5/4 of film such as 29.97 and 23.976.
+func checkPulldownRates(film, video Rate) error {
+	if film.den == 0 || video.den == 0 || video.num*film.den*4 != film.num*video.den*5 {
+		return fmt.Errorf("pulldown needs a video rate 5/4 of the film rate but got: %s and %s", film, video)
+	}
+	return nil
+}
+
+// floorDiv returns a / b rounded toward negative infinity for a positive b.
+func floorDiv(a, b int64) int64 {
+	q := a / b
+	if a%b < 0 {
+		q--
+	}
+	return q
+}
+
+// floorMod returns the remainder of floorDiv which is between 0 and b-1 for a positive b.
+func floorMod(a, b int64) int64 {
+	m := a % b
+	if m < 0 {
+		m += b
+	}
+	return m
+}
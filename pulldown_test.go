@@ -0,0 +1,109 @@
+package timecode
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPulldownCadence(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		pulldown Pulldown
+		cadence  []string
+		first    []int64
+		second   []int64
+	}{
+		{Pulldown23, []string{"AA", "BB", "BC", "CD", "DD"}, []int64{0, 1, 1, 2, 3}, []int64{0, 1, 2, 3, 3}},
+		{Pulldown2332, []string{"AA", "BB", "BC", "CC", "DD"}, []int64{0, 1, 1, 2, 3}, []int64{0, 1, 2, 2, 3}},
+		{Pulldown23.WithPhase(2), []string{"BC", "CD", "DD", "AA", "BB"}, []int64{0, 1, 2, 3, 4}, []int64{1, 2, 2, 3, 4}},
+		{Pulldown23.WithPhase(-1), []string{"DD", "AA", "BB", "BC", "CD"}, []int64{0, 1, 2, 2, 3}, []int64{0, 1, 2, 3, 4}},
+	}
+
+	for _, test := range tests {
+		for cycle := int64(-2); cycle < 3; cycle++ {
+			for i := int64(0); i < 5; i++ {
+				video := FromFrames(R2997DF, cycle*5+i)
+
+				assert.Equal(t, test.cadence[i], test.pulldown.Cadence(video), test.pulldown.String())
+
+				fields, err := test.pulldown.Fields(video, R2398)
+				assert.Nil(t, err)
+				assert.Equal(t, cycle*4+test.first[i], fields.First.Frames())
+				assert.Equal(t, cycle*4+test.second[i], fields.Second.Frames())
+				assert.Equal(t, test.cadence[i][0] != test.cadence[i][1], fields.Split())
+				assert.Equal(t, test.cadence[i][:1], test.pulldown.Letter(fields.First))
+				assert.Equal(t, test.cadence[i][1:], test.pulldown.Letter(fields.Second))
+			}
+		}
+	}
+
+	assert.Equal(t, 4, Pulldown23.WithPhase(9).Phase())
+	assert.Equal(t, "2:3:3:2", Pulldown2332.String())
+}
+
+func TestPulldownRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for _, pulldown := range []Pulldown{Pulldown23, Pulldown2332} {
+		for phase := 0; phase < 5; phase++ {
+			pulldown := pulldown.WithPhase(phase)
+
+			for _, video := range []Rate{R2997, R2997DF} {
+				previous := int64(-1 << 40)
+				for frames := int64(-100); frames < 100; frames++ {
+					film := FromFrames(R2398, frames)
+
+					tc, err := pulldown.ToVideo(film, video)
+					assert.Nil(t, err)
+					assert.Equal(t, video, tc.Rate())
+					assert.True(t, tc.Frames() > previous)
+					previous = tc.Frames()
+
+					back, err := pulldown.ToFilm(tc, R2398)
+					assert.Nil(t, err)
+					assert.Equal(t, film, back)
+				}
+
+				for frames := int64(-100); frames < 100; frames++ {
+					tc, err := pulldown.ToFilm(FromFrames(video, frames), R2398)
+					assert.Nil(t, err)
+
+					first, err := pulldown.ToVideo(tc, video)
+					assert.Nil(t, err)
+					assert.LessOrEqual(t, first.Frames(), frames)
+				}
+			}
+		}
+	}
+}
+
+func TestPulldownTimecodes(t *testing.T) {
+	t.Parallel()
+
+	film, err := Parse(R2398, "01:00:00:00")
+	assert.Nil(t, err)
+
+	video, err := Pulldown23.ToVideo(film, R2997)
+	assert.Nil(t, err)
+	assert.Equal(t, "01:00:00:00", video.String())
+	assert.Equal(t, "A", Pulldown23.Letter(film))
+
+	video, err = Pulldown23.ToVideo(film, R2997DF)
+	assert.Nil(t, err)
+	assert.Equal(t, "01:00:03;18", video.String())
+
+	film, err = Pulldown23.ToFilm(FromFrames(R30, 3), R24)
+	assert.Nil(t, err)
+	assert.Equal(t, "00:00:00:02", film.String())
+
+	_, err = Pulldown23.ToVideo(film, R25)
+	assert.NotNil(t, err)
+
+	_, err = Pulldown23.Fields(FromFrames(R2997, 0), R24)
+	assert.NotNil(t, err)
+
+	_, err = Pulldown23.ToVideo(FromFrames(R2398, 1<<62), R2997)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,319 @@
+package timecode
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Range is a span of Timecodes that starts at the in point and ends just before the out point, written
+// [in, out). The out point is exclusive so a Range from 01:00:00:00 to 01:00:10:00 holds 10 seconds of
+// frames and its last frame is 01:00:09:{fps-1}. Use NewRangeInclusive to build a Range from an inclusive
+// last frame. A Range where in and out are equal is empty. The in and out points are always at the start
+// of a frame, never at the second field or a sub-frame, so a Range holds whole frames. Ranges don't wrap so
+// the out point is never before the in point even when the Rate uses Wrap24Hour. Operations on Ranges with
+// different Rates panic with an ErrRateMismatch error the same as Compare.
+type Range struct {
+	in  Timecode
+	out Timecode
+}
+
+// NewRange returns the Range [in, out). An ErrRateMismatch error is returned if in and out do not use the
+// same Rate and an error is returned if out is before in or if either is at the second field or a sub-frame
+// of a frame.
+func NewRange(in, out Timecode) (Range, error) {
+	if in.rate != out.rate {
+		return Range{}, fmt.Errorf("%w: %s and %s", ErrRateMismatch, in.rate, out.rate)
+	}
+
+	if !in.frameAligned() || !out.frameAligned() {
+		return Range{}, fmt.Errorf("range in point %s and out point %s must be at the start of a frame", in, out)
+	}
+
+	if out.frames < in.frames {
+		return Range{}, fmt.Errorf("range out point %s is before in point %s", out, in)
+	}
+
+	if _, err := subFrames(out.frames, in.frames); err != nil {
+		return Range{}, err
+	}
+
+	return Range{in: in, out: out}, nil
+}
+
+// NewRangeInclusive returns the Range from in through last including last. This is the same as the Range
+// [in, last+1). When the Rate uses Wrap24Hour and last is 23:59:59:{fps-1} the out point is 24:00:00:00.
+func NewRangeInclusive(in, last Timecode) (Range, error) {
+	if !last.frameAligned() {
+		return Range{}, fmt.Errorf("range last frame %s must be at the start of a frame", last)
+	}
+
+	frames, err := addFrames(last.frames, 1)
+	if err != nil {
+		return Range{}, err
+	}
+
+	return NewRange(in, Timecode{rate: last.rate, frames: frames})
+}
+
+// In returns the first frame of the Range.
+func (r Range) In() Timecode {
+	return r.in
+}
+
+// Out returns the exclusive out point of the Range which is the first frame after the Range.
+func (r Range) Out() Timecode {
+	return r.out
+}
+
+// Last returns the inclusive out point of the Range which is the last frame in the Range. Last is one frame
+// before In for an empty Range.
+func (r Range) Last() Timecode {
+	return Timecode{rate: r.out.rate, frames: r.out.frames - 1}
+}
+
+// Rate returns the Rate of the Range.
+func (r Range) Rate() Rate {
+	return r.in.rate
+}
+
+// Frames returns the number of frames in the Range.
+func (r Range) Frames() int64 {
+	return r.out.frames - r.in.frames
+}
+
+// Duration returns the length of the Range as a Timecode such as 00:00:10:00.
+func (r Range) Duration() Timecode {
+	return Timecode{rate: r.in.rate, frames: r.Frames()}
+}
+
+// Empty returns true if the Range has no frames.
+func (r Range) Empty() bool {
+	return r.in.frames == r.out.frames
+}
+
+// Contains returns true if tc is in the Range. The out point isn't in the Range.
+func (r Range) Contains(tc Timecode) bool {
+	return !tc.Before(r.in) && tc.Before(r.out)
+}
+
+// Overlaps returns true if r and other have at least one frame in common. Ranges that only touch such as
+// [1, 2) and [2, 3) don't overlap and empty Ranges never overlap.
+func (r Range) Overlaps(other Range) bool {
+	if r.Empty() || other.Empty() {
+		return false
+	}
+	return r.in.Before(other.out) && other.in.Before(r.out)
+}
+
+// Intersect returns the frames that r and other have in common. The returned bool is false if they don't
+// overlap.
+func (r Range) Intersect(other Range) (Range, bool) {
+	if !r.Overlaps(other) {
+		return Range{}, false
+	}
+
+	return Range{in: Max(r.in, other.in), out: Min(r.out, other.out)}, true
+}
+
+// Union returns the Range covering both r and other. The returned bool is false if r and other neither
+// overlap nor touch since the result wouldn't be a single Range. Use RangeSet to join Ranges with gaps.
+func (r Range) Union(other Range) (Range, bool) {
+	if r.in.After(other.out) || other.in.After(r.out) {
+		return Range{}, false
+	}
+
+	return Range{in: Min(r.in, other.in), out: Max(r.out, other.out)}, true
+}
+
+// Subtract returns the parts of r that aren't in other. The result has no Ranges if other covers r, two
+// Ranges if other is inside of r and one Range otherwise.
+func (r Range) Subtract(other Range) []Range {
+	if !r.Overlaps(other) {
+		if r.Empty() {
+			return nil
+		}
+		return []Range{r}
+	}
+
+	var ranges []Range
+	if r.in.Before(other.in) {
+		ranges = append(ranges, Range{in: r.in, out: other.in})
+	}
+	if other.out.Before(r.out) {
+		ranges = append(ranges, Range{in: other.out, out: r.out})
+	}
+
+	return ranges
+}
+
+// Split returns the Ranges [in, at) and [at, out). The returned bool is false unless at is after the in
+// point and before the out point so both Ranges have at least one frame. It's also false if at is at the
+// second field or a sub-frame of a frame.
+func (r Range) Split(at Timecode) (Range, Range, bool) {
+	if !at.frameAligned() || !at.After(r.in) || !at.Before(r.out) {
+		return Range{}, Range{}, false
+	}
+
+	return Range{in: r.in, out: at}, Range{in: at, out: r.out}, true
+}
+
+// String returns the Range in the form [in, out) such as [01:00:00:00, 01:00:10:00).
+func (r Range) String() string {
+	return "[" + r.in.String() + ", " + r.out.String() + ")"
+}
+
+// RangeSet is a normalized collection of Ranges. The Ranges are sorted, don't overlap or touch and none are
+// empty so a RangeSet holding [1, 3) and [2, 5) holds the single Range [1, 5). The zero value is an empty
+// RangeSet. Operations on RangeSets with different Rates panic with an ErrRateMismatch error the same as
+// Compare.
+type RangeSet struct {
+	ranges []Range
+}
+
+// NewRangeSet returns a RangeSet holding the passed Ranges merging any that overlap or touch. An
+// ErrRateMismatch error is returned if the Ranges do not all use the same Rate.
+func NewRangeSet(ranges ...Range) (RangeSet, error) {
+	for _, r := range ranges {
+		if r.in.rate != ranges[0].in.rate {
+			return RangeSet{}, fmt.Errorf("%w: %s and %s", ErrRateMismatch, ranges[0].in.rate, r.in.rate)
+		}
+	}
+
+	return normalizeRanges(append([]Range(nil), ranges...)), nil
+}
+
+// Ranges returns the sorted Ranges in the RangeSet.
+func (s RangeSet) Ranges() []Range {
+	return append([]Range(nil), s.ranges...)
+}
+
+// Len returns the number of Ranges in the RangeSet.
+func (s RangeSet) Len() int {
+	return len(s.ranges)
+}
+
+// Frames returns the total number of frames in all Ranges of the RangeSet.
+func (s RangeSet) Frames() int64 {
+	var frames int64
+	for _, r := range s.ranges {
+		frames += r.Frames()
+	}
+	return frames
+}
+
+// Contains returns true if tc is in one of the Ranges of the RangeSet.
+func (s RangeSet) Contains(tc Timecode) bool {
+	i := sort.Search(len(s.ranges), func(i int) bool {
+		return tc.Before(s.ranges[i].out)
+	})
+
+	return i < len(s.ranges) && s.ranges[i].Contains(tc)
+}
+
+// Add returns a RangeSet holding the Ranges of s and r.
+func (s RangeSet) Add(r Range) RangeSet {
+	return s.Union(RangeSet{ranges: []Range{r}})
+}
+
+// Union returns a RangeSet holding the frames that are in s or other.
+func (s RangeSet) Union(other RangeSet) RangeSet {
+	ranges := make([]Range, 0, len(s.ranges)+len(other.ranges))
+	ranges = append(ranges, s.ranges...)
+	ranges = append(ranges, other.ranges...)
+	return normalizeRanges(ranges)
+}
+
+// Intersect returns a RangeSet holding the frames that are in both s and other.
+func (s RangeSet) Intersect(other RangeSet) RangeSet {
+	var ranges []Range
+
+	for i, j := 0, 0; i < len(s.ranges) && j < len(other.ranges); {
+		a, b := s.ranges[i], other.ranges[j]
+		if r, ok := a.Intersect(b); ok {
+			ranges = append(ranges, r)
+		}
+
+		if a.out.Before(b.out) {
+			i++
+		} else {
+			j++
+		}
+	}
+
+	return RangeSet{ranges: ranges}
+}
+
+// Subtract returns a RangeSet holding the frames that are in s but not in other.
+func (s RangeSet) Subtract(other RangeSet) RangeSet {
+	var ranges []Range
+
+	j := 0
+	for _, r := range s.ranges {
+		// skip the ranges of other that end before r starts
+		for j < len(other.ranges) && !other.ranges[j].out.After(r.in) {
+			j++
+		}
+
+		in := r.in
+		for k := j; k < len(other.ranges) && other.ranges[k].in.Before(r.out); k++ {
+			if other.ranges[k].in.After(in) {
+				ranges = append(ranges, Range{in: in, out: other.ranges[k].in})
+			}
+			if other.ranges[k].out.After(in) {
+				in = other.ranges[k].out
+			}
+		}
+
+		if in.Before(r.out) {
+			ranges = append(ranges, Range{in: in, out: r.out})
+		}
+	}
+
+	return RangeSet{ranges: ranges}
+}
+
+// String returns the Ranges of the RangeSet separated by commas such as
+// [01:00:00:00, 01:00:10:00), [01:00:20:00, 01:00:30:00).
+func (s RangeSet) String() string {
+	parts := make([]string, len(s.ranges))
+	for i, r := range s.ranges {
+		parts[i] = r.String()
+	}
+	return strings.Join(parts, ", ")
+}
+
+// normalizeRanges sorts ranges, drops the empty ones and merges the ones that overlap or touch. The
+// passed slice is reused.
+func normalizeRanges(ranges []Range) RangeSet {
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].in.Before(ranges[j].in)
+	})
+
+	merged := ranges[:0]
+	for _, r := range ranges {
+		if r.Empty() {
+			continue
+		}
+
+		if n := len(merged); n > 0 && !r.in.After(merged[n-1].out) {
+			if r.out.After(merged[n-1].out) {
+				merged[n-1].out = r.out
+			}
+			continue
+		}
+
+		merged = append(merged, r)
+	}
+
+	if len(merged) == 0 {
+		return RangeSet{}
+	}
+	return RangeSet{ranges: merged}
+}
+
+// frameAligned returns true if tc is at the start of its frame rather than at the second field or a
+// sub-frame.
+func (tc Timecode) frameAligned() bool {
+	return !tc.secondField && tc.subFrame == 0
+}
@@ -0,0 +1,198 @@
+package timecode
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testRange(in, out int64) Range {
+	r, err := NewRange(FromFrames(R25, in), FromFrames(R25, out))
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+func TestNewRange(t *testing.T) {
+	t.Parallel()
+
+	in, err := Parse(R25, "01:00:00:00")
+	assert.Nil(t, err)
+	out, err := Parse(R25, "01:00:10:00")
+	assert.Nil(t, err)
+
+	r, err := NewRange(in, out)
+	assert.Nil(t, err)
+	assert.Equal(t, in, r.In())
+	assert.Equal(t, out, r.Out())
+	assert.Equal(t, "01:00:09:24", r.Last().String())
+	assert.Equal(t, int64(250), r.Frames())
+	assert.Equal(t, "00:00:10:00", r.Duration().String())
+	assert.Equal(t, R25, r.Rate())
+	assert.False(t, r.Empty())
+	assert.Equal(t, "[01:00:00:00, 01:00:10:00)", r.String())
+
+	inclusive, err := NewRangeInclusive(in, r.Last())
+	assert.Nil(t, err)
+	assert.Equal(t, r, inclusive)
+
+	empty, err := NewRange(in, in)
+	assert.Nil(t, err)
+	assert.True(t, empty.Empty())
+	assert.Equal(t, int64(0), empty.Frames())
+
+	_, err = NewRange(out, in)
+	assert.NotNil(t, err)
+
+	_, err = NewRange(in, FromFrames(R24, 0))
+	assert.True(t, errors.Is(err, ErrRateMismatch))
+
+	wrapped := R2997DF.WithWrap(Wrap24Hour)
+	last, err := Parse(wrapped, "23:59:59;29")
+	assert.Nil(t, err)
+	r, err = NewRangeInclusive(FromFrames(wrapped, 0), last)
+	assert.Nil(t, err)
+	assert.Equal(t, wrapped.FramesPerDay(), r.Frames())
+	assert.Equal(t, "24:00:00;00", r.Out().String())
+
+	// in and out points must be whole frames so an out point can't be earlier in the same frame
+	r2997sf := R2997.WithSubFrames(SubFrames80)
+	early, err := FromSubFrames(r2997sf, 80)
+	assert.Nil(t, err)
+	late, err := FromSubFrames(r2997sf, 120)
+	assert.Nil(t, err)
+	_, err = NewRange(late, early)
+	assert.NotNil(t, err)
+	_, err = NewRange(early, late)
+	assert.NotNil(t, err)
+	_, err = NewRangeInclusive(early, late)
+	assert.NotNil(t, err)
+
+	field, err := FromFields(R25.WithScanMode(ScanModeInterlaced), 21)
+	assert.Nil(t, err)
+	_, err = NewRange(FromFrames(field.Rate(), 0), field)
+	assert.NotNil(t, err)
+}
+
+func TestRangeContainsOverlaps(t *testing.T) {
+	t.Parallel()
+
+	r := testRange(10, 20)
+
+	assert.False(t, r.Contains(FromFrames(R25, 9)))
+	assert.True(t, r.Contains(FromFrames(R25, 10)))
+	assert.True(t, r.Contains(FromFrames(R25, 19)))
+	assert.False(t, r.Contains(FromFrames(R25, 20)))
+
+	assert.True(t, r.Overlaps(testRange(19, 30)))
+	assert.True(t, r.Overlaps(testRange(0, 11)))
+	assert.True(t, r.Overlaps(testRange(12, 15)))
+	assert.False(t, r.Overlaps(testRange(20, 30)))
+	assert.False(t, r.Overlaps(testRange(0, 10)))
+	assert.False(t, r.Overlaps(testRange(15, 15)))
+
+	assert.Panics(t, func() {
+		r.Contains(FromFrames(R24, 15))
+	})
+
+	// a point inside of a frame is contained by the Range holding the frame but can't split it
+	r2997sf := R2997.WithSubFrames(SubFrames80)
+	frames, err := NewRange(FromFrames(r2997sf, 10), FromFrames(r2997sf, 20))
+	assert.Nil(t, err)
+	inside, err := FromSubFrames(r2997sf, 19*80+40)
+	assert.Nil(t, err)
+	assert.True(t, frames.Contains(inside))
+	_, _, ok := frames.Split(inside)
+	assert.False(t, ok)
+}
+
+func TestRangeSetOperations(t *testing.T) {
+	t.Parallel()
+
+	r := testRange(10, 20)
+
+	i, ok := r.Intersect(testRange(15, 30))
+	assert.True(t, ok)
+	assert.Equal(t, testRange(15, 20), i)
+
+	_, ok = r.Intersect(testRange(20, 30))
+	assert.False(t, ok)
+
+	u, ok := r.Union(testRange(20, 30))
+	assert.True(t, ok)
+	assert.Equal(t, testRange(10, 30), u)
+
+	u, ok = r.Union(testRange(0, 12))
+	assert.True(t, ok)
+	assert.Equal(t, testRange(0, 20), u)
+
+	_, ok = r.Union(testRange(21, 30))
+	assert.False(t, ok)
+
+	assert.Equal(t, []Range{testRange(10, 12), testRange(15, 20)}, r.Subtract(testRange(12, 15)))
+	assert.Equal(t, []Range{testRange(15, 20)}, r.Subtract(testRange(0, 15)))
+	assert.Equal(t, []Range{testRange(10, 15)}, r.Subtract(testRange(15, 25)))
+	assert.Equal(t, []Range{r}, r.Subtract(testRange(20, 25)))
+	assert.Len(t, r.Subtract(testRange(10, 20)), 0)
+	assert.Len(t, r.Subtract(testRange(0, 30)), 0)
+
+	a, b, ok := r.Split(FromFrames(R25, 13))
+	assert.True(t, ok)
+	assert.Equal(t, testRange(10, 13), a)
+	assert.Equal(t, testRange(13, 20), b)
+
+	_, _, ok = r.Split(FromFrames(R25, 10))
+	assert.False(t, ok)
+	_, _, ok = r.Split(FromFrames(R25, 20))
+	assert.False(t, ok)
+}
+
+func TestRangeSet(t *testing.T) {
+	t.Parallel()
+
+	s, err := NewRangeSet(testRange(30, 40), testRange(0, 10), testRange(5, 15), testRange(15, 20), testRange(50, 50))
+	assert.Nil(t, err)
+	assert.Equal(t, []Range{testRange(0, 20), testRange(30, 40)}, s.Ranges())
+	assert.Equal(t, 2, s.Len())
+	assert.Equal(t, int64(30), s.Frames())
+	assert.Equal(t, "[00:00:00:00, 00:00:00:20), [00:00:01:05, 00:00:01:15)", s.String())
+
+	assert.True(t, s.Contains(FromFrames(R25, 0)))
+	assert.True(t, s.Contains(FromFrames(R25, 19)))
+	assert.False(t, s.Contains(FromFrames(R25, 20)))
+	assert.True(t, s.Contains(FromFrames(R25, 35)))
+	assert.False(t, s.Contains(FromFrames(R25, 40)))
+	assert.False(t, s.Contains(FromFrames(R25, -1)))
+
+	added := s.Add(testRange(20, 30))
+	assert.Equal(t, []Range{testRange(0, 40)}, added.Ranges())
+	assert.Equal(t, 2, s.Len())
+
+	other, err := NewRangeSet(testRange(10, 35), testRange(38, 60))
+	assert.Nil(t, err)
+
+	assert.Equal(t, []Range{testRange(0, 60)}, s.Union(other).Ranges())
+	assert.Equal(t, []Range{testRange(10, 20), testRange(30, 35), testRange(38, 40)}, s.Intersect(other).Ranges())
+	assert.Equal(t, []Range{testRange(0, 10), testRange(35, 38)}, s.Subtract(other).Ranges())
+	assert.Equal(t, []Range{testRange(20, 30), testRange(40, 60)}, other.Subtract(s).Ranges())
+
+	holes, err := NewRangeSet(testRange(2, 4), testRange(6, 8), testRange(18, 32))
+	assert.Nil(t, err)
+	assert.Equal(t, []Range{testRange(0, 2), testRange(4, 6), testRange(8, 18), testRange(32, 40)}, s.Subtract(holes).Ranges())
+
+	var empty RangeSet
+	assert.Equal(t, 0, empty.Len())
+	assert.False(t, empty.Contains(FromFrames(R25, 0)))
+	assert.Equal(t, s, empty.Union(s))
+	assert.Equal(t, 0, s.Intersect(empty).Len())
+	assert.Equal(t, s, s.Subtract(empty))
+
+	empty, err = NewRangeSet()
+	assert.Nil(t, err)
+	assert.Equal(t, 0, empty.Len())
+
+	_, err = NewRangeSet(testRange(0, 10), Range{in: FromFrames(R24, 0), out: FromFrames(R24, 10)})
+	assert.True(t, errors.Is(err, ErrRateMismatch))
+}
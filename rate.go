@@ -3,124 +3,293 @@ package timecode
 import (
 	"fmt"
 	"math"
+	"math/big"
 	"regexp"
 	"strconv"
+	"strings"
 )
 
 var (
-	rateRegExp = regexp.MustCompile(`^(\d+)/(\d+)$`)
+	rateRegExp = regexp.MustCompile(`^(?:(\d+)/(\d+)|(\d+(?:\.\d+)?))\s*([pPiI])?\s*((?i:n?df))?(?:\s+(\d+)\s*(?i:subframes))?(?:\s+(?i:wrap\s+(24h|error)))?$`)
 )
 
 var (
 	// R2297 is SMPTE (Society of Motion Picture and Television Engineers) 29.97 fps NDF
-	R2997 = Rate{fps: 29.97, timeBase: 30, dropFrame: false}
+	R2997 = Rate{num: 30000, den: 1001, timeBase: 30, dropFrame: false}
 
 	// R2997DF is SMPTE (Society of Motion Picture and Television Engineers) 29.97 fps DF
-	R2997DF = Rate{fps: 29.97, timeBase: 30, dropFrame: true}
+	R2997DF = Rate{num: 30000, den: 1001, timeBase: 30, dropFrame: true}
 
 	// R30 is SMPTE (Society of Motion Picture and Television Engineers) 30 fps NDF
-	R30 = Rate{fps: 30, timeBase: 30, dropFrame: false}
+	R30 = Rate{num: 30, den: 1, timeBase: 30, dropFrame: false}
 
 	// R5994 is SMPTE (Society of Motion Picture and Television Engineers) 59.94 fps NDF
-	R5994 = Rate{fps: 59.94, timeBase: 60, dropFrame: false}
+	R5994 = Rate{num: 60000, den: 1001, timeBase: 60, dropFrame: false}
 
 	// R5994DF is SMPTE (Society of Motion Picture and Television Engineers) 59.94 fps DF
-	R5994DF = Rate{fps: 59.94, timeBase: 60, dropFrame: true}
+	R5994DF = Rate{num: 60000, den: 1001, timeBase: 60, dropFrame: true}
 
 	// R60 is SMTPE (Society of Motion Picture and Television Engineers) 60 fps NDF
-	R60 = Rate{fps: 60, timeBase: 60, dropFrame: false}
+	R60 = Rate{num: 60, den: 1, timeBase: 60, dropFrame: false}
 
 	// R25 is EBU (European Broadcasting Union 25 fps NDF
-	R25 = Rate{fps: 25, timeBase: 25, dropFrame: false}
+	R25 = Rate{num: 25, den: 1, timeBase: 25, dropFrame: false}
 
 	// R50 is EBU (European Broadcasting Union 50 fps NDF
-	R50 = Rate{fps: 50, timeBase: 50, dropFrame: false}
+	R50 = Rate{num: 50, den: 1, timeBase: 50, dropFrame: false}
 
-	// R2398 is Film 23.99 fps NDF
-	R2398 = Rate{fps: 23.98, timeBase: 24, dropFrame: false}
+	// R2398 is Film 23.976 (24000/1001) fps NDF
+	R2398 = Rate{num: 24000, den: 1001, timeBase: 24, dropFrame: false}
 
-	// R24 is Film 23.99 fps NDF
-	R24 = Rate{fps: 24.0, timeBase: 24, dropFrame: false}
+	// R24 is Film 24 fps NDF
+	R24 = Rate{num: 24, den: 1, timeBase: 24, dropFrame: false}
 
 	// R120 is Film 120 fps NDF
-	R120 = Rate{fps: 120, timeBase: 120, dropFrame: false}
+	R120 = Rate{num: 120, den: 1, timeBase: 120, dropFrame: false}
 
 	// R240 is SMTPE 240 fps NDF
-	R240 = Rate{fps: 240, timeBase: 240, dropFrame: false}
+	R240 = Rate{num: 240, den: 1, timeBase: 240, dropFrame: false}
 )
 
-// Rate describes a frame rate and drop frame encoding for a Timecode.
+// Rate describes a frame rate and drop frame encoding for a Timecode. The frame rate is stored as
+// an exact num/den ratio so 29.97 is really 30000/1001 and 23.976 is really 24000/1001. The
+// timeBase is the whole number of frames counted per second in a timecode label. The Wrap mode of a Rate
+// controls what happens to its Timecodes at midnight and defaults to WrapNone. The ScanMode of a Rate is
+// either progressive or interlaced and defaults to ScanModeProgressive. A Rate may also divide each frame
+// into sub-frames, see WithSubFrames.
 type Rate struct {
-	fps       float64
-	timeBase  float64
+	num       int64
+	den       int64
+	timeBase  int64
 	dropFrame bool
+	wrap      Wrap
+	scan      ScanMode
+	subFrames int64
 }
 
+// maxFPS is the upper limit of the fps accepted by NewRate so the fps in hundredths fits in an int64.
+const maxFPS = math.MaxInt64 / 100
+
 // NewRate returns a Rate baed on the given fps (frame rate) and dropFrame. The
-// fps must be an integer greater than or equal to 1.  The resulting FPS is rounded
-// to two decimal places. A drop frame timecode is a SMPTE standard that works by
-// skipping two frames per minute except for every 10th minute.
+// fps must be a finite number greater than or equal to 1 and small enough for its hundredths to fit
+// in an int64. Rates within 0.005 of an NTSC rate such as 23.976, 29.97 or 59.94 are stored as the
+// exact n*1000/1001 ratio. Any other fps is rounded to two decimal places. A drop frame timecode is
+// a SMPTE standard that works by skipping two frames per minute except for every 10th minute.
 func NewRate(fps float64, dropFrame bool) (Rate, error) {
 	rate := Rate{}
 
-	fps = math.Round(fps*100) / 100
-
+	if math.IsNaN(fps) || math.IsInf(fps, 0) {
+		return rate, fmt.Errorf("rate must be a finite number but got: %f", fps)
+	}
 	if fps < 1 {
 		return rate, fmt.Errorf("rate must be at least 1 fps but got: %f", fps)
 	}
+	if fps >= maxFPS {
+		return rate, fmt.Errorf("rate must be less than %d fps but got: %f", int64(maxFPS), fps)
+	}
 
 	timeBase := math.Round(fps)
+	ntsc := timeBase * 1000 / 1001
 
-	return Rate{
-		fps:       fps,
-		timeBase:  timeBase,
-		dropFrame: dropFrame,
-	}, nil
+	var num, den int64
+	if fps != timeBase && math.Abs(fps-ntsc) < 0.005 {
+		num, den = int64(timeBase)*1000, 1001
+	} else {
+		num, den = int64(math.Round(fps*100)), 100
+	}
+
+	return newRate(num, den, dropFrame), nil
 }
 
-// ParseRate takes a frame rate string in a fractional form and returns a Rate object.
-// The string is of the form num/den where num is an integer that's greater than 0 and
-// den is an integer greater than 1. The resulting FPS is rounded to two decimal places.
+// ParseRate takes a frame rate string and returns a Rate object. The string is either of the form
+// num/den where num and den are integers, such as 30000/1001, or a decimal such as 29.97 or 23.976.
+// Decimals close to an NTSC rate are stored as the exact n*1000/1001 ratio the same as NewRate. The
+// rate may be followed by p for progressive or i for interlaced, in which case the number is the
+// field rate, so 50i is 25 fps and 59.94i is 29.97 fps and the Rate uses ScanModeInterlaced. A trailing
+// DF or NDF sets drop frame encoding and takes precedence over dropFrame. A trailing number followed
+// by subframes divides each frame of a progressive rate into that many sub-frames. A final wrap 24h or
+// wrap error sets the Wrap mode to Wrap24Hour or WrapError. For example 29.97DF, 59.94 NDF, 25p, 50i,
+// 24 80 subframes and 30000/1001 DF wrap 24h are all valid. The rate must be at least 1 fps, drop frame
+// is only allowed for 29.97 and 59.94 and interlaced rates can't use sub-frames.
 func ParseRate(s string, dropFrame bool) (Rate, error) {
 	rate := Rate{
 		dropFrame: dropFrame,
 	}
 
-	matches := rateRegExp.FindStringSubmatch(s)
-	if len(matches) != 3 {
+	matches := rateRegExp.FindStringSubmatch(strings.TrimSpace(s))
+	if len(matches) != 8 {
 		return rate, fmt.Errorf("unable to parse rate: %s", s)
 	}
 
-	num, err := strconv.ParseFloat(matches[1], 64)
+	var subFrames int64
+	if matches[6] != "" {
+		var err error
+		subFrames, err = strconv.ParseInt(matches[6], 10, 64)
+		if err != nil || subFrames < 1 {
+			return rate, fmt.Errorf("unable to parse rate sub-frames: %s", s)
+		}
+	}
+
+	var wrap Wrap
+	switch strings.ToLower(matches[7]) {
+	case "24h":
+		wrap = Wrap24Hour
+	case "error":
+		wrap = WrapError
+	}
+
+	switch strings.ToUpper(matches[5]) {
+	case "DF":
+		dropFrame = true
+	case "NDF":
+		dropFrame = false
+	}
+
+	interlaced := strings.ToLower(matches[4]) == "i"
+	if interlaced && subFrames > 0 {
+		return rate, fmt.Errorf("%w: %s", errInterlacedSubFrames, s)
+	}
+
+	if matches[3] != "" {
+		fps, err := strconv.ParseFloat(matches[3], 64)
+		if err != nil {
+			return rate, fmt.Errorf("unable to parse rate: %s: %w", s, err)
+		}
+		if interlaced {
+			fps /= 2
+		}
+
+		rate, err = NewRate(fps, dropFrame)
+		if err != nil {
+			return rate, err
+		}
+		if interlaced {
+			rate.scan = ScanModeInterlaced
+		}
+		rate.subFrames = subFrames
+		rate.wrap = wrap
+		return rate, validateDropFrame(rate)
+	}
+
+	num, err := strconv.ParseInt(matches[1], 10, 64)
 	if err != nil {
 		return rate, fmt.Errorf("unable to parse rate numerator: %s: %w", s, err)
 	}
 
-	den, err := strconv.ParseFloat(matches[2], 64)
+	den, err := strconv.ParseInt(matches[2], 10, 64)
 	if err != nil {
 		return rate, fmt.Errorf("unable to parse rate denominator: %s: %w", s, err)
 	}
 	if den == 0 {
 		return rate, fmt.Errorf("rate cannot have a denominator of 0: %s", s)
 	}
+	if interlaced {
+		den *= 2
+	}
 
-	// round to two decimal places
-	fps := num / den
-	if fps < 1 {
-		return rate, fmt.Errorf("rate must be at least 1 fps but got: %f", fps)
+	if num < den {
+		return rate, fmt.Errorf("rate must be at least 1 fps but got: %f", float64(num)/float64(den))
+	}
+
+	rate = newRate(num, den, dropFrame)
+	if interlaced {
+		rate.scan = ScanModeInterlaced
+	}
+	rate.subFrames = subFrames
+	rate.wrap = wrap
+	return rate, validateDropFrame(rate)
+}
+
+// validateDropFrame returns an ErrInvalidDropFrame error if the Rate uses drop frame encoding but
+// isn't one of the SMPTE rates that define it.
+func validateDropFrame(rate Rate) error {
+	if !rate.dropFrame {
+		return nil
 	}
 
-	rate.fps = math.Round(fps*100) / 100
-	return rate, nil
+	if rate.den != 1001 || (rate.timeBase != 30 && rate.timeBase != 60) {
+		return fmt.Errorf("%w: %s", ErrInvalidDropFrame, rate)
+	}
 
+	return nil
 }
 
-// FPS returns the fps (frame rate).
+// newRate builds a Rate from a num/den ratio reducing it to its lowest terms.
+func newRate(num, den int64, dropFrame bool) Rate {
+	d := gcd(num, den)
+	num, den = num/d, den/d
+
+	return Rate{
+		num:       num,
+		den:       den,
+		timeBase:  (num + den/2) / den,
+		dropFrame: dropFrame,
+	}
+}
+
+// FPS returns the fps (frame rate) rounded to two decimal places. Use Ratio, Num and Den
+// when the exact frame rate is needed.
 func (r Rate) FPS() float64 {
-	return r.fps
+	return math.Round(float64(r.num)/float64(r.den)*100) / 100
+}
+
+// Num returns the numerator of the exact frame rate. For example 30000 for 29.97.
+func (r Rate) Num() int64 {
+	return r.num
+}
+
+// Den returns the denominator of the exact frame rate. For example 1001 for 29.97.
+func (r Rate) Den() int64 {
+	return r.den
+}
+
+// Ratio returns the exact frame rate in frames per second as a rational number.
+func (r Rate) Ratio() *big.Rat {
+	return big.NewRat(r.num, r.den)
 }
 
 // DropFrame returns true if this Rate is using SMTPE drop frame encoding.
 func (r Rate) DropFrame() bool {
 	return r.dropFrame
 }
+
+// String returns the exact frame rate in the form num/den followed by DF for drop frame rates.
+// For example 30000/1001 DF. Interlaced rates are written as the field rate followed by i the same
+// as ParseRate accepts them, for example 60000/1001i DF. Rates with sub-frames are followed by the number
+// of sub-frames, for example 30000/1001 DF 80 subframes, and rates using Wrap24Hour or WrapError end
+// with wrap 24h or wrap error.
+func (r Rate) String() string {
+	num, den, scan := r.num, r.den, ""
+	if r.scan == ScanModeInterlaced {
+		d := gcd(num*2, den)
+		num, den, scan = num*2/d, den/d, "i"
+	}
+
+	s := fmt.Sprintf("%d/%d%s", num, den, scan)
+	if r.dropFrame {
+		s += " DF"
+	}
+	if r.subFrames > 0 {
+		s += fmt.Sprintf(" %d subframes", r.subFrames)
+	}
+	switch r.wrap {
+	case Wrap24Hour:
+		s += " wrap 24h"
+	case WrapError:
+		s += " wrap error"
+	}
+	return s
+}
+
+// dropFrames returns the number of frame labels skipped each minute (except every 10th minute)
+// when using drop frame encoding. This is 2 for 29.97 and 4 for 59.94.
+func (r Rate) dropFrames() int64 {
+	return (r.timeBase*2 + 15) / 30
+}
+
+func gcd(a, b int64) int64 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
@@ -1,6 +1,8 @@
 package timecode
 
 import (
+	"errors"
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -24,6 +26,15 @@ func TestNewRate(t *testing.T) {
 
 	_, err = NewRate(-100, true)
 	assert.NotNil(t, err)
+
+	for _, fps := range []float64{math.NaN(), math.Inf(1), math.Inf(-1), 1e17, math.MaxFloat64} {
+		_, err = NewRate(fps, false)
+		assert.NotNil(t, err, "%f", fps)
+	}
+
+	rate, err = NewRate(1e15, false)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1e15), rate.Num())
 }
 
 func TestSMTPERates(t *testing.T) {
@@ -87,3 +98,129 @@ func TestParseRate(t *testing.T) {
 	_, err = ParseRate("", false)
 	assert.NotNil(t, err)
 }
+
+func TestExactRates(t *testing.T) {
+	assert.Equal(t, int64(30000), R2997.Num())
+	assert.Equal(t, int64(1001), R2997.Den())
+	assert.Equal(t, "30000/1001", R2997DF.Ratio().String())
+	assert.Equal(t, "24000/1001", R2398.Ratio().String())
+	assert.Equal(t, "60000/1001", R5994.Ratio().String())
+	assert.Equal(t, "25/1", R25.Ratio().String())
+
+	rate, err := NewRate(29.97, true)
+	assert.Nil(t, err)
+	assert.Equal(t, R2997DF, rate)
+
+	rate, err = NewRate(23.976, false)
+	assert.Nil(t, err)
+	assert.Equal(t, R2398, rate)
+
+	rate, err = NewRate(23.98, false)
+	assert.Nil(t, err)
+	assert.Equal(t, R2398, rate)
+
+	rate, err = NewRate(59.94, false)
+	assert.Nil(t, err)
+	assert.Equal(t, R5994, rate)
+
+	rate, err = NewRate(12.5, false)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(25), rate.Num())
+	assert.Equal(t, int64(2), rate.Den())
+
+	rate, err = ParseRate("30000/1001", true)
+	assert.Nil(t, err)
+	assert.Equal(t, R2997DF, rate)
+
+	rate, err = ParseRate("50/2", false)
+	assert.Nil(t, err)
+	assert.Equal(t, R25, rate)
+}
+
+func TestRateString(t *testing.T) {
+	assert.Equal(t, "30000/1001 DF", R2997DF.String())
+	assert.Equal(t, "30000/1001", R2997.String())
+	assert.Equal(t, "25/1", R25.String())
+}
+
+func TestParseRateForms(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		s         string
+		dropFrame bool
+		expected  Rate
+	}{
+		{"30000/1001", false, R2997},
+		{"30000/1001", true, R2997DF},
+		{"24000/1001", false, R2398},
+		{"60000/1001", true, R5994DF},
+		{"25/1", false, R25},
+		{"29.97", false, R2997},
+		{"29.97", true, R2997DF},
+		{"23.976", false, R2398},
+		{"23.98", false, R2398},
+		{"59.94", false, R5994},
+		{"24", false, R24},
+		{"29.97DF", false, R2997DF},
+		{"29.97 DF", false, R2997DF},
+		{"29.97df", false, R2997DF},
+		{"29.97NDF", true, R2997},
+		{"59.94 NDF", false, R5994},
+		{"30000/1001 DF", false, R2997DF},
+		{"25p", false, R25},
+		{"50i", false, R25.WithScanMode(ScanModeInterlaced)},
+		{"59.94i", false, R2997.WithScanMode(ScanModeInterlaced)},
+		{"59.94i DF", false, R2997DF.WithScanMode(ScanModeInterlaced)},
+		{"60000/1001i", false, R2997.WithScanMode(ScanModeInterlaced)},
+		{"120000/1001i", false, R5994.WithScanMode(ScanModeInterlaced)},
+		{" 50p ", false, R50},
+		{"30000/1001 DF wrap 24h", false, R2997DF.WithWrap(Wrap24Hour)},
+		{"25 wrap error", false, R25.WithWrap(WrapError)},
+		{"24 80 subframes WRAP 24H", false, R24.WithSubFrames(SubFrames80).WithWrap(Wrap24Hour)},
+	}
+
+	for _, test := range tests {
+		rate, err := ParseRate(test.s, test.dropFrame)
+		assert.Nil(t, err, test.s)
+		assert.Equal(t, test.expected, rate, test.s)
+
+		// String is always accepted by ParseRate
+		back, err := ParseRate(rate.String(), false)
+		assert.Nil(t, err, rate.String())
+		assert.Equal(t, rate, back, rate.String())
+	}
+
+	for _, s := range []string{"25 DF", "24DF", "23.976 DF", "30DF", "30/1 DF", "50i DF"} {
+		_, err := ParseRate(s, false)
+		assert.True(t, errors.Is(err, ErrInvalidDropFrame), s)
+	}
+
+	_, err := ParseRate("25", true)
+	assert.True(t, errors.Is(err, ErrInvalidDropFrame))
+
+	for _, s := range []string{"", "DF", "29.97x", "29.97 DF DF", "0.5", "1/2", "1i", "30/0", "-25", "25.", ".5", "25 wrap", "25 wrap 12h"} {
+		_, err := ParseRate(s, false)
+		assert.NotNil(t, err, s)
+	}
+}
+
+func TestParsedRateTimecodes(t *testing.T) {
+	t.Parallel()
+
+	rate, err := ParseRate("30000/1001", true)
+	assert.Nil(t, err)
+
+	tc, err := Parse(rate, "01:30:12;15")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(162213), tc.Frames())
+	assert.Equal(t, "01:30:12;15", tc.String())
+
+	rate, err = ParseRate("23.976", false)
+	assert.Nil(t, err)
+
+	tc, err = Parse(rate, "01:30:12:15")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(129903), tc.Frames())
+	assert.Equal(t, "01:30:12:15", tc.String())
+}
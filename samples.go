@@ -0,0 +1,126 @@
+package timecode
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// maxSampleCadence is the longest cycle of frames SampleCadence returns. Common rates repeat within 100
+// frames but an unusual Rate could otherwise need billions of entries.
+const maxSampleCadence = 1 << 20
+
+// FromSamples returns a Timecode based on the passed rate and a position counted in audio samples at
+// sampleRate samples per second, such as 48000. The result is the frame, or sub-frame when the Rate uses
+// sub-frames, that contains the sample. Negative samples result in a negative Timecode. An error is
+// returned if sampleRate is less than 1. The result is wrapped or checked according to the Wrap mode of
+// the Rate.
+func FromSamples(rate Rate, sampleRate, samples int64) (Timecode, error) {
+	tc := Timecode{
+		rate: rate,
+	}
+
+	if sampleRate < 1 {
+		return tc, fmt.Errorf("sample rate must be at least 1 but got: %d", sampleRate)
+	}
+
+	base := rate.subFrames
+	if base == 0 {
+		base = 1
+	}
+
+	// samples * fps / sampleRate is the position in frames
+	position := new(big.Int).Mul(big.NewInt(samples), big.NewInt(rate.num))
+	position.Mul(position, big.NewInt(base))
+	perSecond := new(big.Int).Mul(big.NewInt(rate.den), big.NewInt(sampleRate))
+	total := round(new(big.Rat).SetFrac(position, perSecond), RoundFloor)
+	if !total.IsInt64() {
+		if total.Sign() < 0 {
+			return tc, fmt.Errorf("%w: timecode can not represent %d samples", ErrUnderflow, samples)
+		}
+		return tc, fmt.Errorf("%w: timecode can not represent %d samples", ErrOverflow, samples)
+	}
+
+	frames, err := rate.bound(floorDiv(total.Int64(), base))
+	if err != nil {
+		return tc, err
+	}
+
+	tc.frames = frames
+	tc.subFrame = floorMod(total.Int64(), base)
+	return tc, nil
+}
+
+// Samples returns the position of the Timecode in audio samples at sampleRate samples per second, such as
+// 48000. This is the first sample at or after the start of the frame, or sub-frame when the Rate uses
+// sub-frames, so FromSamples converts it back to the same Timecode as long as a frame lasts at least one
+// sample. An error is returned if sampleRate is less than 1 and an ErrOverflow or ErrUnderflow error is
+// returned if the samples don't fit in an int64.
+func (tc Timecode) Samples(sampleRate int64) (int64, error) {
+	if sampleRate < 1 {
+		return 0, fmt.Errorf("sample rate must be at least 1 but got: %d", sampleRate)
+	}
+
+	samples := new(big.Rat).Mul(tc.position(), big.NewRat(tc.rate.den, tc.rate.num))
+	samples.Mul(samples, big.NewRat(sampleRate, 1))
+
+	rounded := round(samples, RoundCeil)
+	if !rounded.IsInt64() {
+		if rounded.Sign() < 0 {
+			return 0, fmt.Errorf("%w: %s can not be represented as samples", ErrUnderflow, tc)
+		}
+		return 0, fmt.Errorf("%w: %s can not be represented as samples", ErrOverflow, tc)
+	}
+
+	return rounded.Int64(), nil
+}
+
+// SamplesPerFrame returns the exact number of audio samples in a frame at sampleRate samples per second,
+// such as 8008/5 for 48000 at 29.97 fps. An error is returned if sampleRate is less than 1.
+func (r Rate) SamplesPerFrame(sampleRate int64) (*big.Rat, error) {
+	if sampleRate < 1 {
+		return nil, fmt.Errorf("sample rate must be at least 1 but got: %d", sampleRate)
+	}
+	if r.num == 0 {
+		return nil, fmt.Errorf("unable to count samples per frame for rate: %s", r)
+	}
+
+	return new(big.Rat).SetFrac(
+		new(big.Int).Mul(big.NewInt(sampleRate), big.NewInt(r.den)),
+		big.NewInt(r.num),
+	), nil
+}
+
+// SampleCadence returns the number of audio samples in each frame at sampleRate samples per second for the
+// shortest cycle of frames that holds a whole number of samples, starting at frame 0. The cycle repeats so
+// frame n has SampleCadence()[n mod len] samples. For example 48000 at 29.97 fps returns 1602, 1602, 1601,
+// 1602 and 1601 which is 8008 samples every 5 frames. An error is returned if sampleRate is less than 1 or
+// the cycle is longer than 1048576 frames.
+//
+// The phase of the cycle comes from the timeline rather than from a standard. Frame 0 starts on sample 0
+// and every frame starts on the sample returned by Timecode.Samples, so summing the cadence always lands on
+// the first sample of a frame and FromSamples agrees with it. The 48 kHz sequence of SMPTE 272M and 299M
+// embedded audio, 1602, 1601, 1602, 1601, 1602, is the same cycle starting at frame 1. Embedded audio
+// carries its position in the sequence with the audio, not the timecode, so callers matching it should
+// rotate the returned cadence to the audio frame number of the stream.
+func (r Rate) SampleCadence(sampleRate int64) ([]int64, error) {
+	perFrame, err := r.SamplesPerFrame(sampleRate)
+	if err != nil {
+		return nil, err
+	}
+
+	if !perFrame.Denom().IsInt64() || perFrame.Denom().Int64() > maxSampleCadence {
+		return nil, fmt.Errorf("sample cadence of %s at %d is longer than %d frames", r, sampleRate, maxSampleCadence)
+	}
+
+	cycle := perFrame.Denom().Int64()
+	cadence := make([]int64, cycle)
+
+	start := big.NewInt(0)
+	for i := range cadence {
+		end := round(new(big.Rat).Mul(perFrame, big.NewRat(int64(i)+1, 1)), RoundCeil)
+		cadence[i] = new(big.Int).Sub(end, start).Int64()
+		start = end
+	}
+
+	return cadence, nil
+}
@@ -0,0 +1,175 @@
+package timecode
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSamples(t *testing.T) {
+	t.Parallel()
+
+	samples, err := FromFrames(R2997, 1).Samples(48000)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1602), samples)
+
+	samples, err = FromFrames(R2997, 5).Samples(48000)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(8008), samples)
+
+	samples, err = FromFrames(R25, -1).Samples(48000)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(-1920), samples)
+
+	tc, err := FromSamples(R2997, 48000, 1601)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), tc.Frames())
+
+	tc, err = FromSamples(R2997, 48000, 1602)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), tc.Frames())
+
+	tc, err = FromSamples(R25, 48000, -1)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(-1), tc.Frames())
+
+	// 1601.6 samples per frame is 20.02 samples per sub-frame
+	tc, err = FromSamples(r2997sf, 48000, 1602+21)
+	assert.Nil(t, err)
+	assert.Equal(t, "00:00:00:01.01", tc.String())
+
+	samples, err = tc.Samples(48000)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1622), samples)
+
+	for _, rate := range []Rate{R2997, R2997DF, R25, R24, r2997sf} {
+		for _, frames := range []int64{0, 1, 17, 1799, 107892, -3} {
+			tc := FromFrames(rate, frames)
+			samples, err := tc.Samples(48000)
+			assert.Nil(t, err)
+
+			back, err := FromSamples(rate, 48000, samples)
+			assert.Nil(t, err)
+			assert.Equal(t, tc, back, "%s %d", rate, frames)
+		}
+	}
+
+	_, err = FromSamples(R25, 0, 1)
+	assert.NotNil(t, err)
+
+	_, err = FromFrames(R25, 0).Samples(-1)
+	assert.NotNil(t, err)
+
+	_, err = FromFrames(R25, math.MaxInt64).Samples(48000)
+	assert.True(t, errors.Is(err, ErrOverflow))
+
+	_, err = FromSamples(R24.WithWrap(WrapError), 48000, math.MaxInt64)
+	assert.True(t, errors.Is(err, ErrOverflow))
+}
+
+func TestSamplesRates(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		rate       Rate
+		sampleRate int64
+		frames     int64
+		samples    int64
+	}{
+		{R2997DF, 48000, 107892, 172799828},
+		{R2997, 44100, 1, 1472},
+		{R2997, 44100, 100, 147147},
+		{R2997, 96000, 5, 16016},
+		{R2398, 48000, 24, 48048},
+		{R2398, 44100, 80, 147147},
+		{R25, 96000, 90000, 345600000},
+		{R24, 44100, 1, 1838},
+	}
+
+	for _, test := range tests {
+		tc := FromFrames(test.rate, test.frames)
+
+		samples, err := tc.Samples(test.sampleRate)
+		assert.Nil(t, err)
+		assert.Equal(t, test.samples, samples, "%s at %d", tc, test.sampleRate)
+
+		back, err := FromSamples(test.rate, test.sampleRate, samples)
+		assert.Nil(t, err)
+		assert.Equal(t, tc, back)
+
+		// the sample before the frame belongs to the previous frame
+		back, err = FromSamples(test.rate, test.sampleRate, samples-1)
+		assert.Nil(t, err)
+		assert.Equal(t, test.frames-1, back.Frames())
+	}
+}
+
+func TestSamplesPerFrame(t *testing.T) {
+	t.Parallel()
+
+	perFrame, err := R2997.SamplesPerFrame(48000)
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewRat(8008, 5), perFrame)
+
+	perFrame, err = R25.SamplesPerFrame(48000)
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewRat(1920, 1), perFrame)
+
+	_, err = R25.SamplesPerFrame(0)
+	assert.NotNil(t, err)
+
+	_, err = Rate{}.SamplesPerFrame(48000)
+	assert.NotNil(t, err)
+}
+
+func TestSampleCadence(t *testing.T) {
+	t.Parallel()
+
+	cadence, err := R2997DF.SampleCadence(48000)
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{1602, 1602, 1601, 1602, 1601}, cadence)
+
+	// the SMPTE 299M sequence is the same cycle starting at frame 1
+	assert.Equal(t, []int64{1602, 1601, 1602, 1601, 1602}, append(cadence[1:], cadence[0]))
+
+	cadence, err = R2997.SampleCadence(96000)
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{3204, 3203, 3203, 3203, 3203}, cadence)
+
+	cadence, err = R2398.SampleCadence(48000)
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{2002}, cadence)
+
+	cadence, err = R25.SampleCadence(44100)
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{1764}, cadence)
+
+	// the cadence matches the samples of each frame and sums to whole samples
+	for _, sampleRate := range []int64{44100, 48000, 96000} {
+		for _, rate := range []Rate{R2398, R2997, R5994, R24} {
+			cadence, err := rate.SampleCadence(sampleRate)
+			assert.Nil(t, err)
+
+			var start int64
+			for i, samples := range cadence {
+				first, err := FromFrames(rate, int64(i)).Samples(sampleRate)
+				assert.Nil(t, err)
+				assert.Equal(t, start, first)
+				start += samples
+			}
+
+			end, err := FromFrames(rate, int64(len(cadence))).Samples(sampleRate)
+			assert.Nil(t, err)
+			assert.Equal(t, start, end)
+		}
+	}
+
+	_, err = R25.SampleCadence(0)
+	assert.NotNil(t, err)
+
+	_, err = Rate{num: math.MaxInt64, den: 1, timeBase: 1}.SampleCadence(48000)
+	assert.NotNil(t, err)
+}
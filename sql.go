@@ -0,0 +1,63 @@
+package timecode
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// Value implements driver.Valuer. The Rate is stored as text in the same form as String such as
+// 30000/1001 DF. The zero Rate is stored as NULL.
+func (r Rate) Value() (driver.Value, error) {
+	if r.den == 0 {
+		return nil, nil
+	}
+	return r.String(), nil
+}
+
+// Scan implements sql.Scanner. The value must be text in any form accepted by ParseRate. NULL results
+// in the zero Rate.
+func (r *Rate) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*r = Rate{}
+		return nil
+	case string:
+		return r.UnmarshalText([]byte(v))
+	case []byte:
+		return r.UnmarshalText(v)
+	}
+
+	return fmt.Errorf("unable to scan %T into rate", src)
+}
+
+// Value implements driver.Valuer. The Timecode is stored as text in the same form as MarshalText which is
+// the label and Rate separated by an @ such as 01:00:00;00@30000/1001 DF. The label and exact rate keep the
+// frame count, drop frame encoding and Wrap mode so a stored Timecode scans back to the same Timecode. The
+// zero Timecode is stored as NULL.
+func (tc Timecode) Value() (driver.Value, error) {
+	if tc.rate.den == 0 {
+		return nil, nil
+	}
+
+	text, err := tc.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return string(text), nil
+}
+
+// Scan implements sql.Scanner. The value must be text in the form label@rate the same as UnmarshalText. NULL
+// results in the zero Timecode.
+func (tc *Timecode) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*tc = Timecode{}
+		return nil
+	case string:
+		return tc.UnmarshalText([]byte(v))
+	case []byte:
+		return tc.UnmarshalText(v)
+	}
+
+	return fmt.Errorf("unable to scan %T into timecode", src)
+}
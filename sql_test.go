@@ -0,0 +1,107 @@
+package timecode
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	_ sql.Scanner   = (*Timecode)(nil)
+	_ driver.Valuer = Timecode{}
+	_ sql.Scanner   = (*Rate)(nil)
+	_ driver.Valuer = Rate{}
+)
+
+func TestRateSQL(t *testing.T) {
+	t.Parallel()
+
+	value, err := R2997DF.Value()
+	assert.Nil(t, err)
+	assert.Equal(t, "30000/1001 DF", value)
+
+	var rate Rate
+	assert.Nil(t, rate.Scan(value))
+	assert.Equal(t, R2997DF, rate)
+
+	assert.Nil(t, rate.Scan([]byte("25")))
+	assert.Equal(t, R25, rate)
+
+	assert.Nil(t, rate.Scan(nil))
+	assert.Equal(t, Rate{}, rate)
+
+	value, err = Rate{}.Value()
+	assert.Nil(t, err)
+	assert.Nil(t, value)
+
+	assert.NotNil(t, rate.Scan(int64(25)))
+	assert.NotNil(t, rate.Scan("25 DF"))
+}
+
+func TestTimecodeSQL(t *testing.T) {
+	t.Parallel()
+
+	tcs := []Timecode{
+		FromFrames(R2997DF, 107892),
+		FromFrames(R2997, 107892),
+		FromFrames(R5994DF, 215784),
+		FromFrames(R2398, 86313),
+		FromFrames(R25, -37),
+		FromFrames(R2997DF.WithWrap(Wrap24Hour), 2589407),
+		FromFrames(R25.WithWrap(WrapError), 90000),
+	}
+
+	for _, tc := range tcs {
+		value, err := tc.Value()
+		assert.Nil(t, err)
+
+		var back Timecode
+		assert.Nil(t, back.Scan(value))
+		assert.Equal(t, tc, back)
+
+		back = Timecode{}
+		assert.Nil(t, back.Scan([]byte(value.(string))))
+		assert.Equal(t, tc, back)
+	}
+
+	value, err := FromFrames(R2997DF, 107892).Value()
+	assert.Nil(t, err)
+	assert.Equal(t, "01:00:00;00@30000/1001 DF", value)
+
+	// the Wrap mode is kept so the scanned Timecode can be compared with the original
+	wrapped := FromFrames(R2997DF.WithWrap(Wrap24Hour), 107892)
+	value, err = wrapped.Value()
+	assert.Nil(t, err)
+	assert.Equal(t, "01:00:00;00@30000/1001 DF wrap 24h", value)
+	var back Timecode
+	assert.Nil(t, back.Scan(value))
+	diff, err := Diff(back, wrapped)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), diff)
+
+	var tc Timecode
+	assert.Nil(t, tc.Scan("01:00:00;00@30000/1001"))
+	assert.Equal(t, FromFrames(R2997DF, 107892), tc)
+
+	assert.Nil(t, tc.Scan("01:00:00:00@30000/1001"))
+	assert.Equal(t, FromFrames(R2997, 108000), tc)
+
+	assert.Nil(t, tc.Scan("01:00:00;00@30000/1001 NDF"))
+	assert.Equal(t, FromFrames(R2997, 108000), tc)
+
+	assert.Nil(t, tc.Scan("01:00:00;00@25"))
+	assert.Equal(t, FromFrames(R25, 90000), tc)
+
+	assert.Nil(t, tc.Scan(nil))
+	assert.Equal(t, Timecode{}, tc)
+
+	value, err = Timecode{}.Value()
+	assert.Nil(t, err)
+	assert.Nil(t, value)
+
+	assert.NotNil(t, tc.Scan(int64(107892)))
+	assert.NotNil(t, tc.Scan("00:01:00;00@30000/1001"))
+	assert.NotNil(t, tc.Scan("01:00:00;00"))
+}
@@ -0,0 +1,153 @@
+package timecode
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+)
+
+const (
+	// SubFrames80 is the number of sub-frames per frame used by SMPTE bits and many audio workstations.
+	SubFrames80 = 80
+
+	// SubFrames100 is the number of sub-frames per frame used by Pro Tools and other audio workstations.
+	SubFrames100 = 100
+)
+
+// WithSubFrames returns a copy of the Rate where each frame is divided into subFrames sub-frames such as
+// SubFrames80 or SubFrames100. Timecodes of the Rate carry a sub-frame component that is formatted after
+// the frames such as 01:00:00:00.45. A subFrames of 0 or less removes sub-frames from the Rate. Timecodes
+// with the same frame rate but a different number of sub-frames use different Rates and can't be compared.
+// WithSubFrames panics if subFrames is more than 0 and the Rate is interlaced.
+func (r Rate) WithSubFrames(subFrames int) Rate {
+	if subFrames > 0 && r.scan == ScanModeInterlaced {
+		panic(fmt.Errorf("%w: %s", errInterlacedSubFrames, r))
+	}
+
+	r.subFrames = 0
+	if subFrames > 0 {
+		r.subFrames = int64(subFrames)
+	}
+	return r
+}
+
+// SubFrames returns the number of sub-frames in each frame or 0 if the Rate doesn't use sub-frames.
+func (r Rate) SubFrames() int {
+	return int(r.subFrames)
+}
+
+// FromSubFrames returns a Timecode based on the passed rate and total number of sub-frames. For example
+// 85 is sub-frame 5 of frame 1 at SubFrames80. Negative sub-frames count back from the start of frame 0 so
+// -1 is the last sub-frame of frame -1. An error is returned if the Rate doesn't use sub-frames. Like
+// FromFrames the result is wrapped when the Rate uses Wrap24Hour.
+func FromSubFrames(rate Rate, subFrames int64) (Timecode, error) {
+	if rate.subFrames == 0 {
+		return Timecode{rate: rate}, fmt.Errorf("sub-frames require a rate with sub-frames but got: %s", rate)
+	}
+
+	frames, err := rate.bound(floorDiv(subFrames, rate.subFrames))
+	if err != nil {
+		return Timecode{rate: rate}, err
+	}
+
+	return Timecode{rate: rate, frames: frames, subFrame: floorMod(subFrames, rate.subFrames)}, nil
+}
+
+// SubFrame returns the sub-frame portion of the timecode string as a uint64. For example a timecode of
+// 01:00:00:00.45 would return 45. The sign of a negative Timecode applies to the sub-frame as well as the
+// frames so -00:00:00:01.60 is 1.75 frames before 00:00:00:00 and returns 60.
+func (tc Timecode) SubFrame() uint64 {
+	if tc.frames < 0 && tc.subFrame > 0 {
+		return uint64(tc.rate.subFrames - tc.subFrame)
+	}
+	return uint64(tc.subFrame)
+}
+
+// SubFrames returns the total number of sub-frames from the start of frame 0 to the Timecode. An
+// ErrOverflow or ErrUnderflow error is returned if the sub-frames don't fit in an int64.
+func (tc Timecode) SubFrames() (int64, error) {
+	base := tc.rate.subFrames
+	if base == 0 {
+		base = 1
+	}
+
+	if tc.frames > (math.MaxInt64-tc.subFrame)/base {
+		return 0, fmt.Errorf("%w: %s has too many sub-frames", ErrOverflow, tc)
+	}
+	if tc.frames < math.MinInt64/base {
+		return 0, fmt.Errorf("%w: %s has too many sub-frames", ErrUnderflow, tc)
+	}
+
+	return tc.frames*base + tc.subFrame, nil
+}
+
+// WithSubFrame returns a copy of the Timecode with the same label at sub-frame subFrame. An error is
+// returned if subFrame isn't between 0 and the number of sub-frames of the Rate minus 1.
+func (tc Timecode) WithSubFrame(subFrame int64) (Timecode, error) {
+	if subFrame < 0 || (subFrame > 0 && subFrame >= tc.rate.subFrames) {
+		return tc, fmt.Errorf("sub-frame must be between 0 and %d got: %d", tc.rate.subFrames-1, subFrame)
+	}
+
+	if tc.Negative() {
+		label := Timecode{rate: tc.rate, frames: int64(tc.absFrames()), subFrame: subFrame}
+		return label.negate(), nil
+	}
+
+	tc.subFrame = subFrame
+	return tc, nil
+}
+
+// AddSubFrames adds the sub-frames to the Timecode and returns a new Timecode as the result. Negative
+// sub-frames move the Timecode backwards. An error is returned if the Rate doesn't use sub-frames and an
+// ErrOverflow or ErrUnderflow error is returned if the result can't be represented. The result is wrapped or
+// checked according to the Wrap mode of the Rate.
+func (tc Timecode) AddSubFrames(subFrames int64) (Timecode, error) {
+	base := tc.rate.subFrames
+	if base == 0 {
+		return tc, fmt.Errorf("sub-frames require a rate with sub-frames but got: %s", tc.rate)
+	}
+
+	// carry sub-frames past the end of the frame into the next frame
+	frames := floorDiv(subFrames, base)
+	subFrame := tc.subFrame + floorMod(subFrames, base)
+	if subFrame >= base {
+		frames++
+		subFrame -= base
+	}
+
+	result, err := tc.Add(frames)
+	if err != nil {
+		return result, err
+	}
+
+	result.subFrame = subFrame
+	return result, nil
+}
+
+// position returns the exact position of the Timecode in frames including the second field or the
+// sub-frame. A Rate is never both interlaced and divided into sub-frames so at most one of them is set.
+func (tc Timecode) position() *big.Rat {
+	position := big.NewRat(tc.frames, 1)
+	if tc.secondField {
+		position.Add(position, big.NewRat(1, 2))
+	}
+	if tc.subFrame > 0 {
+		position.Add(position, big.NewRat(tc.subFrame, tc.rate.subFrames))
+	}
+	return position
+}
+
+// parseSubFrame parses the sub-frame digits of a label for rate.
+func parseSubFrame(rate Rate, digits string) (int64, error) {
+	if rate.subFrames == 0 {
+		return 0, fmt.Errorf("sub-frames require a rate with sub-frames but got: %s", rate)
+	}
+
+	subFrame, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil || subFrame >= rate.subFrames {
+		return 0, fmt.Errorf("sub-frame must be between 0 and %d got: %s", rate.subFrames-1, digits)
+	}
+
+	return subFrame, nil
+}
@@ -0,0 +1,271 @@
+package timecode
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var r2997sf = R2997.WithSubFrames(SubFrames80)
+
+func TestSubFrameRates(t *testing.T) {
+	t.Parallel()
+
+	rate, err := ParseRate("29.97 80 subframes", false)
+	assert.Nil(t, err)
+	assert.Equal(t, r2997sf, rate)
+	assert.Equal(t, SubFrames80, rate.SubFrames())
+	assert.Equal(t, "30000/1001 80 subframes", rate.String())
+
+	rate, err = ParseRate(rate.String(), false)
+	assert.Nil(t, err)
+	assert.Equal(t, r2997sf, rate)
+
+	rate, err = ParseRate("29.97 DF 100 Subframes", false)
+	assert.Nil(t, err)
+	assert.Equal(t, R2997DF.WithSubFrames(SubFrames100), rate)
+
+	_, err = ParseRate("25 0 subframes", false)
+	assert.NotNil(t, err)
+
+	assert.Equal(t, 0, R25.SubFrames())
+	assert.Equal(t, R25, R25.WithSubFrames(SubFrames80).WithSubFrames(0))
+
+	// an interlaced rate can't also use sub-frames
+	for _, s := range []string{"50i 80 subframes", "60000/1001i DF 100 subframes"} {
+		_, err = ParseRate(s, false)
+		assert.True(t, errors.Is(err, errInterlacedSubFrames), s)
+	}
+
+	r50i := R25.WithScanMode(ScanModeInterlaced)
+	assert.Panics(t, func() {
+		r50i.WithSubFrames(SubFrames80)
+	})
+	assert.Panics(t, func() {
+		R25.WithSubFrames(SubFrames80).WithScanMode(ScanModeInterlaced)
+	})
+	assert.Equal(t, r50i, r50i.WithSubFrames(0))
+	assert.Equal(t, R25, R25.WithSubFrames(SubFrames80).WithScanMode(ScanModeProgressive).WithSubFrames(0))
+
+	data, err := R25.WithSubFrames(SubFrames80).MarshalBinary()
+	assert.Nil(t, err)
+	data[17] |= rateFlagInterlaced
+	assert.True(t, errors.Is(rate.UnmarshalBinary(data), errInterlacedSubFrames))
+}
+
+func TestParseSubFrames(t *testing.T) {
+	t.Parallel()
+
+	tc, err := Parse(r2997sf, "01:00:00:00.45")
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(45), tc.SubFrame())
+	assert.Equal(t, int64(108000), tc.Frames())
+	assert.Equal(t, "01:00:00:00.45", tc.String())
+
+	tc, err = Parse(r2997sf, "00:00:00:01")
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(0), tc.SubFrame())
+	assert.Equal(t, "00:00:00:01.00", tc.String())
+
+	tc, err = Parse(r2997sf, "-00:00:00:01.60")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(-2), tc.Frames())
+	assert.Equal(t, uint64(60), tc.SubFrame())
+	assert.Equal(t, "-00:00:00:01.60", tc.String())
+	subFrames, err := tc.SubFrames()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(-140), subFrames)
+
+	tc, err = Parse(r2997sf, "-00:00:00:00.20")
+	assert.Nil(t, err)
+	assert.True(t, tc.Negative())
+	assert.Equal(t, uint64(20), tc.SubFrame())
+	assert.Equal(t, "-00:00:00:00.20", tc.String())
+	subFrames, err = tc.SubFrames()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(-20), subFrames)
+
+	// labels are symmetric around zero
+	for n := int64(-250); n <= 250; n += 7 {
+		tc, err = FromSubFrames(r2997sf, n)
+		assert.Nil(t, err)
+		opposite, err := FromSubFrames(r2997sf, -n)
+		assert.Nil(t, err)
+		if n < 0 {
+			assert.Equal(t, "-"+opposite.String(), tc.String())
+		}
+
+		parsed, err := Parse(r2997sf, tc.String())
+		assert.Nil(t, err)
+		assert.Equal(t, tc, parsed)
+	}
+
+	tc, err = Parse(r2997sf.WithWrap(Wrap24Hour), "-00:00:00:00.20")
+	assert.Nil(t, err)
+	assert.Equal(t, "23:59:59:29.60", tc.String())
+
+	_, err = Parse(r2997sf, "00:00:00:01.80")
+	assert.NotNil(t, err)
+
+	_, err = Parse(R2997, "00:00:00:01.45")
+	assert.NotNil(t, err)
+
+	tc, err = ParseWithLayout(r2997sf, "%H:%M:%S:%F+%U", "00:00:01:02+07")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(32), tc.Frames())
+	assert.Equal(t, uint64(7), tc.SubFrame())
+
+	tc, err = ParseWithLayout(r2997sf, "%H:%M:%S:%F+%U", "-00:00:00:00+20")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(-1), tc.Frames())
+	assert.Equal(t, uint64(20), tc.SubFrame())
+	assert.Equal(t, "-00:00:00:00+20", tc.Format("%H:%M:%S:%F+%U"))
+}
+
+func TestSubFrames(t *testing.T) {
+	t.Parallel()
+
+	tc, err := FromSubFrames(r2997sf, 85)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), tc.Frames())
+	assert.Equal(t, uint64(5), tc.SubFrame())
+
+	subFrames, err := tc.SubFrames()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(85), subFrames)
+
+	tc, err = FromSubFrames(r2997sf, -1)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(-1), tc.Frames())
+	assert.Equal(t, uint64(1), tc.SubFrame())
+	assert.Equal(t, "-00:00:00:00.01", tc.String())
+
+	subFrames, err = tc.SubFrames()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(-1), subFrames)
+
+	_, err = FromSubFrames(R2997, 85)
+	assert.NotNil(t, err)
+
+	_, err = FromSubFrames(r2997sf.WithWrap(WrapError), -1)
+	assert.True(t, errors.Is(err, ErrUnderflow))
+
+	// without sub-frames the sub-frames are the frames
+	subFrames, err = FromFrames(R25, 10).SubFrames()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(10), subFrames)
+
+	_, err = FromFrames(r2997sf, math.MaxInt64/2).SubFrames()
+	assert.True(t, errors.Is(err, ErrOverflow))
+
+	_, err = FromFrames(r2997sf, math.MinInt64/2).SubFrames()
+	assert.True(t, errors.Is(err, ErrUnderflow))
+}
+
+func TestWithSubFrame(t *testing.T) {
+	t.Parallel()
+
+	tc, err := FromFrames(r2997sf, 10).WithSubFrame(79)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(79), tc.SubFrame())
+	assert.Equal(t, int64(10), tc.Frames())
+
+	_, err = tc.WithSubFrame(80)
+	assert.NotNil(t, err)
+
+	_, err = tc.WithSubFrame(-1)
+	assert.NotNil(t, err)
+
+	_, err = FromFrames(R25, 10).WithSubFrame(1)
+	assert.NotNil(t, err)
+
+	tc, err = FromFrames(R25, 10).WithSubFrame(0)
+	assert.Nil(t, err)
+	assert.Equal(t, FromFrames(R25, 10), tc)
+
+	tc, err = FromFrames(r2997sf, -1).WithSubFrame(20)
+	assert.Nil(t, err)
+	assert.Equal(t, "-00:00:00:01.20", tc.String())
+}
+
+func TestAddSubFrames(t *testing.T) {
+	t.Parallel()
+
+	tc, err := FromSubFrames(r2997sf, 70)
+	assert.Nil(t, err)
+
+	tc, err = tc.AddSubFrames(15)
+	assert.Nil(t, err)
+	assert.Equal(t, "00:00:00:01.05", tc.String())
+
+	tc, err = tc.AddSubFrames(-10)
+	assert.Nil(t, err)
+	assert.Equal(t, "00:00:00:00.75", tc.String())
+
+	tc, err = tc.AddSubFrames(-160)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(-2), tc.Frames())
+	assert.Equal(t, uint64(5), tc.SubFrame())
+	assert.Equal(t, "-00:00:00:01.05", tc.String())
+
+	tc, err = FromFrames(r2997sf, 5).Add(1)
+	assert.Nil(t, err)
+	tc, err = tc.WithSubFrame(40)
+	assert.Nil(t, err)
+	tc, err = tc.Add(1)
+	assert.Nil(t, err)
+	assert.Equal(t, "00:00:00:07.40", tc.String())
+
+	_, err = FromFrames(R25, 0).AddSubFrames(1)
+	assert.NotNil(t, err)
+
+	_, err = FromFrames(r2997sf, math.MaxInt64).AddSubFrames(80)
+	assert.True(t, errors.Is(err, ErrOverflow))
+}
+
+func TestCompareSubFrames(t *testing.T) {
+	t.Parallel()
+
+	a, _ := FromSubFrames(r2997sf, 81)
+	b, _ := FromSubFrames(r2997sf, 82)
+	c, _ := FromSubFrames(r2997sf, 160)
+
+	assert.True(t, a.Before(b))
+	assert.True(t, b.Before(c))
+	assert.True(t, c.After(a))
+	assert.True(t, a.Equal(a))
+	assert.False(t, a.Equal(FromFrames(r2997sf, 1)))
+}
+
+func TestMarshalSubFrames(t *testing.T) {
+	t.Parallel()
+
+	tc, err := Parse(r2997sf, "01:00:00:00.45")
+	assert.Nil(t, err)
+
+	data, err := json.Marshal(tc)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"timecode":"01:00:00:00.45","rate":"30000/1001 80 subframes"}`, string(data))
+
+	var back Timecode
+	assert.Nil(t, json.Unmarshal(data, &back))
+	assert.Equal(t, tc, back)
+
+	text, err := tc.MarshalText()
+	assert.Nil(t, err)
+	back = Timecode{}
+	assert.Nil(t, back.UnmarshalText(text))
+	assert.Equal(t, tc, back)
+
+	binary, err := tc.MarshalBinary()
+	assert.Nil(t, err)
+	back = Timecode{}
+	assert.Nil(t, back.UnmarshalBinary(binary))
+	assert.Equal(t, tc, back)
+
+	binary[len(binary)-1] = 80
+	assert.NotNil(t, back.UnmarshalBinary(binary))
+}
@@ -1,107 +1,245 @@
 package timecode
 
 import (
+	"errors"
 	"fmt"
 	"math"
+	"math/big"
 	"regexp"
 	"strconv"
 )
 
 var (
-	timecodeRegExp = regexp.MustCompile(`^(\d+)[:;.,](\d\d)[:;.,](\d\d)[:;.,](\d+)$`)
+	timecodeRegExp = regexp.MustCompile(`^(-?)(\d+)[:;.,](\d\d)[:;.,](\d\d)([:;.,])(\d+)(?:\.(\d+))?$`)
 )
 
 // Timecode is used to simplify using string based timecodes by providing conversions, frame based math,
 // and support for SMTPE drop frame encoding. This timecode library supports hours of any length and does
-// not loop back to 00:00:00:00 after 59:59:59:{fps-1}.
+// not loop back to 00:00:00:00 after 59:59:59:{fps-1} unless the Rate uses a different Wrap mode. A Timecode may
+// also be negative, which is useful for offsets such as -00:00:01:12. A negative Timecode is labeled as the positive
+// Timecode with a leading minus sign. For interlaced Rates a Timecode also identifies the first or second field
+// of its frame and for Rates with sub-frames it carries the sub-frame within its frame.
 type Timecode struct {
-	rate   Rate
-	frames uint64
+	rate        Rate
+	frames      int64
+	secondField bool
+	subFrame    int64
+}
+
+// ParseOption configures optional behavior of Parse.
+type ParseOption func(*parseOptions)
+
+type parseOptions struct {
+	snapDroppedFrames bool
+}
+
+// SnapDroppedFrames returns a ParseOption that advances labels skipped by drop frame encoding, such as
+// 00:01:00;00 and 00:01:00;01 at 29.97 DF, to the next valid label instead of returning ErrDroppedFrame.
+func SnapDroppedFrames() ParseOption {
+	return func(o *parseOptions) {
+		o.snapDroppedFrames = true
+	}
 }
 
 // Parse takes rate and a timecode as a string in the form hh:mm:ss:ff. Where hh represents hours, mm represents minutes,
 // ss represents seconds, and ff represents frames. Minutes and seconds must between 0 and 59. Hours and frames must be
-// greather than or equal to 0. Hours, minutes, seconds, or frames less than 10 must be left padded with a 0. This means
-// that negative timecodes are not supported. The separator isn't required to be : and will match any of [:;,.] in any position.
-// Parse is written to be as forgiving as possible.
-func Parse(rate Rate, s string) (Timecode, error) {
+// greather than or equal to 0. Hours, minutes, seconds, or frames less than 10 must be left padded with a 0. A leading
+// minus sign such as -00:00:01:12 results in a negative timecode. The separator isn't required to be : and will match any
+// of [:;,.] in any position. Parse is written to be as forgiving as possible. For drop frame rates, labels that
+// drop frame encoding skips, such as 00:01:00;00 at 29.97 DF, return an ErrDroppedFrame error unless the
+// SnapDroppedFrames option is passed. Labels outside of 00:00:00:00 to 23:59:59:{fps-1} are wrapped when the Rate
+// uses Wrap24Hour and return an error when the Rate uses WrapError. For interlaced rates a . or , before the
+// frames marks the second field of the frame such as 01:00:00.12 or 01:00:00,12. For rates with sub-frames the
+// frames may be followed by a . and the sub-frame such as 01:00:00:00.45.
+func Parse(rate Rate, s string, opts ...ParseOption) (Timecode, error) {
 	tc := Timecode{
 		rate: rate,
 	}
 
+	options := parseOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
 	matches := timecodeRegExp.FindStringSubmatch(s)
-	if len(matches) != 5 {
+	if len(matches) != 8 {
 		return tc, fmt.Errorf("unable to parse timecode: %s", s)
 	}
 
-	hours, err := strconv.ParseFloat(matches[1], 64)
+	hours, err := strconv.ParseUint(matches[2], 10, 64)
 	if err != nil {
 		return tc, fmt.Errorf("unable to parse timecode hours: %s: %w", s, err)
 	}
 
-	minutes, err := strconv.ParseFloat(matches[2], 64)
+	minutes, err := strconv.ParseUint(matches[3], 10, 64)
 	if err != nil {
 		return tc, fmt.Errorf("unable to parse timecode minutes: %s: %w", s, err)
 	}
 	if minutes >= 60 {
-		return tc, fmt.Errorf("minutes must be between 0 and 59 got: %d", uint64(minutes))
+		return tc, fmt.Errorf("minutes must be between 0 and 59 got: %d", minutes)
 	}
 
-	seconds, err := strconv.ParseFloat(matches[3], 64)
+	seconds, err := strconv.ParseUint(matches[4], 10, 64)
 	if err != nil {
 		return tc, fmt.Errorf("unable to parse timecode minutes: %s: %w", s, err)
 	}
 	if seconds >= 60 {
-		return tc, fmt.Errorf("minutes must be between 0 and 59 got: %d", uint64(seconds))
+		return tc, fmt.Errorf("minutes must be between 0 and 59 got: %d", seconds)
 	}
 
-	frames, err := strconv.ParseFloat(matches[4], 64)
+	frames, err := strconv.ParseUint(matches[6], 10, 64)
 	if err != nil {
 		return tc, fmt.Errorf("unable to parse timecode minutes: %s: %w", s, err)
 	}
-	if frames >= tc.rate.timeBase {
-		return tc, fmt.Errorf("frames must be between 0 and %f got: %d", tc.rate.fps, uint64(frames))
+	if frames >= uint64(tc.rate.timeBase) {
+		return tc, fmt.Errorf("frames must be between 0 and %f got: %d", tc.rate.FPS(), frames)
 	}
 
-	hourFrames := rate.timeBase * 3600
-	minutesFrames := rate.timeBase * 60
-	totalFrames := hourFrames*hours + minutesFrames*minutes + rate.timeBase*seconds + frames
+	if isDroppedLabel(rate, minutes, seconds, frames) {
+		if !options.snapDroppedFrames {
+			return tc, fmt.Errorf("%w: %s", ErrDroppedFrame, s)
+		}
+		frames = uint64(rate.dropFrames())
+	}
 
-	if rate.dropFrame {
-		dropFrames := math.Round(rate.fps * 0.066666)
-		totalMinutes := (60 * hours) + minutes
-		// remove skipped frames from the frame count
-		frames := totalFrames - (dropFrames * (totalMinutes - totalMinutes/10))
-		tc.frames = uint64(frames)
-	} else {
-		tc.frames = uint64(totalFrames)
+	tc.frames, err = labelToFrames(rate, hours, minutes, seconds, frames)
+	if err != nil {
+		return tc, fmt.Errorf("%w: %s", err, s)
+	}
+
+	if matches[7] != "" {
+		tc.subFrame, err = parseSubFrame(rate, matches[7])
+		if err != nil {
+			return tc, fmt.Errorf("%w: %s", err, s)
+		}
+	}
+
+	tc.secondField = rate.scan == ScanModeInterlaced && (matches[5] == "." || matches[5] == ",")
+
+	if matches[1] == "-" {
+		tc = tc.negate()
+	}
+
+	tc.frames, err = rate.bound(tc.frames)
+	if err != nil {
+		return tc, fmt.Errorf("%w: %s", err, s)
 	}
 
 	return tc, nil
 }
 
-// FromFrames returns a Timecode based on the passed rate and frames.
-func FromFrames(rate Rate, frames uint64) Timecode {
+// FromFrames returns a Timecode based on the passed rate and frames. Negative frames result
+// in a negative timecode. The frames are wrapped to 24 hours when the Rate uses Wrap24Hour. FromFrames
+// doesn't check the frames of a Rate using WrapError.
+func FromFrames(rate Rate, frames int64) Timecode {
+	if rate.wrap == Wrap24Hour {
+		frames = rate.wrapDay(frames)
+	}
+
 	return Timecode{
 		rate:   rate,
 		frames: frames,
 	}
 }
 
-// FromSeconds returns a Timecode based on the passed rate and seconds.
+// FromSeconds returns a Timecode based on the passed rate and real elapsed seconds. The seconds are
+// converted to frames using the exact frame rate of the Rate and truncated toward zero to a whole frame.
+// Negative seconds result in a negative timecode. Seconds returned by Timecode.Seconds always convert
+// back to the same frame. The result is wrapped or checked according to the Wrap mode of the Rate.
 func FromSeconds(rate Rate, seconds float64) (Timecode, error) {
 	tc := Timecode{
 		rate: rate,
 	}
 
+	if math.IsInf(seconds, 0) || math.IsNaN(seconds) {
+		return tc, fmt.Errorf("timecode must have a finite value: %f", seconds)
+	}
+
+	// use the shortest decimal representation of seconds so a value such as 5412.5 is
+	// treated as exactly 5412.5 rather than the nearest binary fraction.
+	exact, _ := new(big.Rat).SetString(strconv.FormatFloat(seconds, 'g', -1, 64))
+	exact.Mul(exact, rate.Ratio())
+	frames := new(big.Int).Quo(exact.Num(), exact.Denom())
+	if !frames.IsInt64() || frames.Int64() == math.MinInt64 || frames.Int64() == math.MaxInt64 {
+		if seconds < 0 {
+			return tc, fmt.Errorf("%w: timecode can not represent %f seconds", ErrUnderflow, seconds)
+		}
+		return tc, fmt.Errorf("%w: timecode can not represent %f seconds", ErrOverflow, seconds)
+	}
+	tc.frames = frames.Int64()
+
+	// the float64 returned by Seconds may be a hair closer to zero than the exact frame boundary
+	step := int64(1)
 	if seconds < 0 {
-		return tc, fmt.Errorf("timecode can not have a negative value: %f", seconds)
+		step = -1
+	}
+	if next := (Timecode{rate: rate, frames: tc.frames + step}); next.Seconds() == seconds {
+		tc.frames = next.frames
 	}
 
-	totalFrames := seconds * rate.timeBase
-	tc.frames = uint64(totalFrames)
+	var err error
+	tc.frames, err = rate.bound(tc.frames)
+	return tc, err
+}
 
-	return tc, nil
+// FromLabelSeconds returns a Timecode based on the passed rate and label seconds. Label seconds count
+// each second of the hh:mm:ss:ff label as timeBase frames which differs from real elapsed time for
+// fractional rates such as 29.97. For example 5412.5 label seconds at R2997DF is 01:30:12;15. The
+// frame is truncated toward zero and labels skipped by drop frame encoding advance to the next label.
+// Seconds returned by Timecode.LabelSeconds always convert back to the same frame. The result is wrapped
+// or checked according to the Wrap mode of the Rate.
+func FromLabelSeconds(rate Rate, seconds float64) (Timecode, error) {
+	tc := Timecode{
+		rate: rate,
+	}
+
+	if math.IsInf(seconds, 0) || math.IsNaN(seconds) {
+		return tc, fmt.Errorf("timecode must have a finite value: %f", seconds)
+	}
+
+	exact, _ := new(big.Rat).SetString(strconv.FormatFloat(math.Abs(seconds), 'g', -1, 64))
+	whole := new(big.Int).Quo(exact.Num(), exact.Denom())
+	if !whole.IsUint64() {
+		if seconds < 0 {
+			return tc, fmt.Errorf("%w: timecode can not represent %f seconds", ErrUnderflow, seconds)
+		}
+		return tc, fmt.Errorf("%w: timecode can not represent %f seconds", ErrOverflow, seconds)
+	}
+
+	fraction := exact.Sub(exact, new(big.Rat).SetInt(whole))
+	fraction.Mul(fraction, big.NewRat(tc.rate.timeBase, 1))
+	frame := new(big.Int).Quo(fraction.Num(), fraction.Denom()).Uint64()
+
+	total := whole.Uint64()
+	hours, minutes, secs := total/3600, total/60%60, total%60
+
+	// advance labels skipped by drop frame encoding
+	if isDroppedLabel(rate, minutes, secs, frame) {
+		frame = uint64(rate.dropFrames())
+	}
+
+	frames, err := labelToFrames(rate, hours, minutes, secs, frame)
+	if err != nil {
+		if seconds < 0 && errors.Is(err, ErrOverflow) {
+			return tc, fmt.Errorf("%w: timecode can not represent %f seconds", ErrUnderflow, seconds)
+		}
+		return tc, fmt.Errorf("%w: timecode can not represent %f seconds", err, seconds)
+	}
+
+	tc.frames = frames
+	step := int64(1)
+	if seconds < 0 {
+		tc.frames = -tc.frames
+		step = -1
+	}
+
+	// the float64 returned by LabelSeconds may be a hair closer to zero than the exact frame boundary
+	if next := (Timecode{rate: rate, frames: tc.frames + step}); next.LabelSeconds() == seconds {
+		tc.frames = next.frames
+	}
+
+	tc.frames, err = rate.bound(tc.frames)
+	return tc, err
 }
 
 // Rate returns the Rate used when creating the Timecode.
@@ -162,61 +300,115 @@ func (tc Timecode) Frame() uint64 {
 }
 
 // String returns the entire timecode formatted as a string based on the frame rate and drop frame
-// encoding.
+// encoding. This is the same as Format(LayoutSMPTE) or Format(LayoutSMPTESubFrames) when the Rate uses
+// sub-frames.
 func (tc Timecode) String() string {
-	var hour, minute, second, frame uint64
-	if tc.rate.dropFrame {
-		hour, minute, second, frame = tc.dropFrameToParts()
-	} else {
-		hour, minute, second, frame = tc.toParts()
+	if tc.rate.subFrames > 0 {
+		return tc.Format(LayoutSMPTESubFrames)
 	}
-
-	sep := ":"
-	if tc.rate.dropFrame {
-		sep = ";"
-	}
-
-	return fmt.Sprintf("%02d:%02d:%02d%s%02d", hour, minute, second, sep, frame)
+	return tc.Format(LayoutSMPTE)
 }
 
 // Frames returns the frames as an int64 based on the frame rate and drop frame
-// encoding.
-func (tc Timecode) Frames() uint64 {
+// encoding. The frames are negative for a negative timecode. The frames of a negative timecode at
+// the second field or a sub-frame are rounded down, so -00:00:00:01.60 is frame -2.
+func (tc Timecode) Frames() int64 {
 	return tc.frames
 }
 
-// Seconds returns the total seconds as an float64 based on the frame rate and drop frame
-// encoding.
+// Negative returns true if the Timecode is less than 00:00:00:00.
+func (tc Timecode) Negative() bool {
+	return tc.frames < 0
+}
+
+// Seconds returns the real elapsed seconds as a float64 based on the exact frame rate. For example
+// a single frame of 29.97 lasts 1001/30000 seconds. Use LabelSeconds for the seconds shown by the
+// hh:mm:ss:ff label.
 func (tc Timecode) Seconds() float64 {
-	hour, minute, second, frame := tc.toParts()
-	return float64(hour*3600+minute*60+second) + float64(frame)/tc.rate.timeBase
+	seconds := tc.position()
+	seconds.Mul(seconds, big.NewRat(tc.rate.den, tc.rate.num))
+	f, _ := seconds.Float64()
+	return f
+}
+
+// LabelSeconds returns the seconds represented by the hh:mm:ss:ff label as a float64. Each second of the
+// label is counted as timeBase frames so for fractional rates such as 29.97 this is not the real elapsed
+// time. For example 01:30:12;15 at R2997DF returns 5412.5 while Seconds returns 5412.5071.
+func (tc Timecode) LabelSeconds() float64 {
+	hour, minute, second, frame := tc.parts()
+
+	timeBase := uint64(tc.rate.timeBase)
+	labelFrames := new(big.Int).SetUint64(hour*3600 + minute*60 + second)
+	labelFrames.Mul(labelFrames, new(big.Int).SetUint64(timeBase))
+	labelFrames.Add(labelFrames, new(big.Int).SetUint64(frame))
+
+	seconds, _ := new(big.Rat).SetFrac(labelFrames, new(big.Int).SetUint64(timeBase)).Float64()
+	if tc.Negative() {
+		return -seconds
+	}
+	return seconds
 }
 
-// Add adds the frames to the Timecode and returns a new Timecode as the result.
-func (tc Timecode) Add(frames uint64) Timecode {
-	return FromFrames(tc.rate, tc.Frames()+frames)
+// Add adds the frames to the Timecode and returns a new Timecode as the result. Negative
+// frames move the Timecode backwards. If the result can't be represented as an int64 frame
+// count an ErrOverflow or ErrUnderflow error is returned. The result is wrapped or checked
+// according to the Wrap mode of the Rate.
+func (tc Timecode) Add(frames int64) (Timecode, error) {
+	if tc.rate.wrap == Wrap24Hour {
+		frames %= tc.rate.FramesPerDay()
+	}
+
+	result, err := addFrames(tc.frames, frames)
+	if err != nil {
+		return Timecode{}, err
+	}
+
+	result, err = tc.rate.bound(result)
+	if err != nil {
+		return Timecode{}, err
+	}
+
+	return Timecode{rate: tc.rate, frames: result, secondField: tc.secondField, subFrame: tc.subFrame}, nil
 }
 
-// Sub subtracts the frames from the Timecode and returns a new Timecode as the result.
-// If the result would be a negative timecode then an error is returned.
-func (tc Timecode) Sub(frames uint64) (Timecode, error) {
-	result := int64(tc.Frames() - frames)
-	if result < 0 {
-		return Timecode{}, fmt.Errorf("resulting timecode would have a negative value: %d", result)
+// Sub subtracts the frames from the Timecode and returns a new Timecode as the result. The
+// result may be a negative Timecode. Negative frames move the Timecode forwards. If the result
+// can't be represented as an int64 frame count an ErrOverflow or ErrUnderflow error is returned.
+// The result is wrapped or checked according to the Wrap mode of the Rate.
+func (tc Timecode) Sub(frames int64) (Timecode, error) {
+	if tc.rate.wrap == Wrap24Hour {
+		frames %= tc.rate.FramesPerDay()
+	}
+
+	result, err := subFrames(tc.frames, frames)
+	if err != nil {
+		return Timecode{}, err
+	}
+
+	result, err = tc.rate.bound(result)
+	if err != nil {
+		return Timecode{}, err
 	}
 
-	return FromFrames(tc.rate, uint64(result)), nil
+	return Timecode{rate: tc.rate, frames: result, secondField: tc.secondField, subFrame: tc.subFrame}, nil
+}
+
+// parts returns the hour, minute, second and frame of the label based on the drop frame encoding.
+func (tc Timecode) parts() (uint64, uint64, uint64, uint64) {
+	if tc.rate.dropFrame {
+		return tc.dropFrameToParts()
+	}
+	return tc.toParts()
 }
 
 func (tc Timecode) dropFrameToParts() (uint64, uint64, uint64, uint64) {
-	dropFrames := uint64(math.Round(tc.rate.fps * 0.066666))
+	timeBase := uint64(tc.rate.timeBase)
+	dropFrames := uint64(tc.rate.dropFrames())
 
-	// framesPerHour := uint64(math.Round(tc.rate.fps * 3600))
-	// framesPer24Hours := framesPerHour * 24
-	framesPer10Minutes := uint64(math.Round(tc.rate.fps * 60 * 10))
-	framesPerMinute := (uint64(math.Round(tc.rate.fps)) * 60) - dropFrames
+	framesPer10Minutes := timeBase*60*10 - dropFrames*9
+	framesPerMinute := (timeBase * 60) - dropFrames
 
-	framenumber := tc.frames
+	framenumber := tc.absFrames()
 	d := framenumber / framesPer10Minutes
 	m := framenumber % framesPer10Minutes
 
@@ -226,20 +418,20 @@ func (tc Timecode) dropFrameToParts() (uint64, uint64, uint64, uint64) {
 		framenumber = framenumber + dropFrames*9*d
 	}
 
-	// frRound = math.Round(framerate);
-	frame := framenumber % uint64(tc.rate.timeBase)
-	second := (framenumber / uint64(tc.rate.timeBase)) % 60
-	minute := ((framenumber / uint64(tc.rate.timeBase)) / 60) % 60
-	hour := (((framenumber / uint64(tc.rate.timeBase)) / 60) / 60)
+	frame := framenumber % timeBase
+	second := (framenumber / timeBase) % 60
+	minute := ((framenumber / timeBase) / 60) % 60
+	hour := (((framenumber / timeBase) / 60) / 60)
 
 	return hour, minute, second, frame
 }
 
 func (tc Timecode) toParts() (uint64, uint64, uint64, uint64) {
-	framesPerHour := uint64(tc.rate.timeBase) * 3600
-	framesPerMinute := uint64(tc.rate.timeBase) * 60
+	timeBase := uint64(tc.rate.timeBase)
+	framesPerHour := timeBase * 3600
+	framesPerMinute := timeBase * 60
 
-	remaining := tc.frames
+	remaining := tc.absFrames()
 
 	hour := remaining / framesPerHour
 	remaining = remaining - (hour * framesPerHour)
@@ -247,8 +439,90 @@ func (tc Timecode) toParts() (uint64, uint64, uint64, uint64) {
 	minute := remaining / framesPerMinute
 	remaining = remaining - (minute * framesPerMinute)
 
-	second := remaining / uint64(tc.rate.timeBase)
-	remaining = remaining - (second * uint64(tc.rate.timeBase))
+	second := remaining / timeBase
+	remaining = remaining - (second * timeBase)
+
+	return hour, minute, second, remaining
+}
+
+// isDroppedLabel returns true if the label is one that drop frame encoding skips. These are the first frames
+// of every minute except every 10th minute.
+func isDroppedLabel(rate Rate, minutes, seconds, frames uint64) bool {
+	return rate.dropFrame && seconds == 0 && minutes%10 != 0 && frames < uint64(rate.dropFrames())
+}
+
+// labelToFrames returns the number of frames represented by the label hours:minutes:seconds:frames.
+func labelToFrames(rate Rate, hours, minutes, seconds, frames uint64) (int64, error) {
+	timeBase := uint64(rate.timeBase)
+	if hours > math.MaxInt64/(timeBase*3600)-1 {
+		return 0, fmt.Errorf("%w: timecode hours are too large: %d", ErrOverflow, hours)
+	}
+
+	totalFrames := timeBase*3600*hours + timeBase*60*minutes + timeBase*seconds + frames
+
+	if rate.dropFrame {
+		dropFrames := uint64(rate.dropFrames())
+		totalMinutes := (60 * hours) + minutes
+		// remove skipped frames from the frame count
+		totalFrames = totalFrames - (dropFrames * (totalMinutes - totalMinutes/10))
+	}
+
+	return int64(totalFrames), nil
+}
+
+// addFrames returns a + b or an error if the result doesn't fit in an int64.
+func addFrames(a, b int64) (int64, error) {
+	if b > 0 && a > math.MaxInt64-b {
+		return 0, fmt.Errorf("%w: %d + %d", ErrOverflow, a, b)
+	}
+	if b < 0 && a < math.MinInt64-b {
+		return 0, fmt.Errorf("%w: %d + %d", ErrUnderflow, a, b)
+	}
 
-	return uint64(hour), uint64(minute), uint64(second), uint64(remaining)
+	return a + b, nil
+}
+
+// subFrames returns a - b or an error if the result doesn't fit in an int64.
+func subFrames(a, b int64) (int64, error) {
+	if b < 0 && a > math.MaxInt64+b {
+		return 0, fmt.Errorf("%w: %d - %d", ErrOverflow, a, b)
+	}
+	if b > 0 && a < math.MinInt64+b {
+		return 0, fmt.Errorf("%w: %d - %d", ErrUnderflow, a, b)
+	}
+
+	return a - b, nil
+}
+
+// absFrames returns the number of frames in the label ignoring the sign of the Timecode. A negative
+// Timecode at the second field or a sub-frame is in the frame after its label, for example
+// -00:00:00:00.20 is in frame -1.
+func (tc Timecode) absFrames() uint64 {
+	if tc.frames < 0 && (tc.secondField || tc.subFrame > 0) {
+		return abs(tc.frames + 1)
+	}
+	return abs(tc.frames)
+}
+
+// negate returns the Timecode at the negated position of tc. The second field and sub-frame count forward
+// from the start of a frame so negating them moves the Timecode to the frame before the negated frames
+// and the label keeps the same field and sub-frame with a minus sign.
+func (tc Timecode) negate() Timecode {
+	switch {
+	case tc.subFrame > 0:
+		tc.frames, tc.subFrame = -tc.frames-1, tc.rate.subFrames-tc.subFrame
+	case tc.secondField:
+		tc.frames = -tc.frames - 1
+	default:
+		tc.frames = -tc.frames
+	}
+	return tc
+}
+
+// abs returns the absolute value of i as a uint64 so it can hold the absolute value of math.MinInt64.
+func abs(i int64) uint64 {
+	if i < 0 {
+		return uint64(-(i + 1)) + 1
+	}
+	return uint64(i)
 }
@@ -1,6 +1,8 @@
 package timecode
 
 import (
+	"errors"
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -12,7 +14,7 @@ func TestParse(t *testing.T) {
 	tc, err := Parse(R30, "00:00:10:00")
 	assert.Nil(t, err)
 	assert.Equal(t, "00:00:10:00", tc.String())
-	assert.Equal(t, uint64(300), tc.Frames())
+	assert.Equal(t, int64(300), tc.Frames())
 	assert.Equal(t, 10.0, tc.Seconds())
 	assert.Equal(t, uint64(0), tc.Hour())
 	assert.Equal(t, uint64(0), tc.Minute())
@@ -22,8 +24,8 @@ func TestParse(t *testing.T) {
 	tc, err = Parse(R2997DF, "00:00:00:01")
 	assert.Nil(t, err)
 	assert.Equal(t, "00:00:00;01", tc.String())
-	assert.Equal(t, uint64(1), tc.Frames())
-	assert.Equal(t, 0.03333333333333333, tc.Seconds())
+	assert.Equal(t, int64(1), tc.Frames())
+	assert.Equal(t, 0.03336666666666667, tc.Seconds())
 	assert.Equal(t, uint64(0), tc.Hour())
 	assert.Equal(t, uint64(0), tc.Minute())
 	assert.Equal(t, uint64(0), tc.Second())
@@ -32,7 +34,7 @@ func TestParse(t *testing.T) {
 	tc, err = Parse(R30, "123:00:10:00")
 	assert.Nil(t, err)
 	assert.Equal(t, "123:00:10:00", tc.String())
-	assert.Equal(t, uint64(13284300), tc.Frames())
+	assert.Equal(t, int64(13284300), tc.Frames())
 	assert.Equal(t, 442810.0, tc.Seconds())
 	assert.Equal(t, uint64(123), tc.Hour())
 	assert.Equal(t, uint64(0), tc.Minute())
@@ -42,7 +44,7 @@ func TestParse(t *testing.T) {
 	tc, err = Parse(R30, "01:30:12:15")
 	assert.Nil(t, err)
 	assert.Equal(t, "01:30:12:15", tc.String())
-	assert.Equal(t, uint64(162375), tc.Frames())
+	assert.Equal(t, int64(162375), tc.Frames())
 	assert.Equal(t, 5412.5, tc.Seconds())
 	assert.Equal(t, uint64(1), tc.Hour())
 	assert.Equal(t, uint64(30), tc.Minute())
@@ -52,8 +54,8 @@ func TestParse(t *testing.T) {
 	tc, err = Parse(R2997DF, "01:30:12;15")
 	assert.Nil(t, err)
 	assert.Equal(t, "01:30:12;15", tc.String())
-	assert.Equal(t, uint64(162213), tc.Frames())
-	assert.Equal(t, 5407.1, tc.Seconds())
+	assert.Equal(t, int64(162213), tc.Frames())
+	assert.Equal(t, 5412.5071, tc.Seconds())
 	assert.Equal(t, uint64(1), tc.Hour())
 	assert.Equal(t, uint64(30), tc.Minute())
 	assert.Equal(t, uint64(12), tc.Second())
@@ -62,8 +64,8 @@ func TestParse(t *testing.T) {
 	tc, err = Parse(R2997, "01:30:12:15")
 	assert.Nil(t, err)
 	assert.Equal(t, "01:30:12:15", tc.String())
-	assert.Equal(t, uint64(162375), tc.Frames())
-	assert.Equal(t, 5412.5, tc.Seconds())
+	assert.Equal(t, int64(162375), tc.Frames())
+	assert.Equal(t, 5417.9125, tc.Seconds())
 	assert.Equal(t, uint64(1), tc.Hour())
 	assert.Equal(t, uint64(30), tc.Minute())
 	assert.Equal(t, uint64(12), tc.Second())
@@ -72,8 +74,8 @@ func TestParse(t *testing.T) {
 	tc, err = Parse(R5994DF, "01:30:12;15")
 	assert.Nil(t, err)
 	assert.Equal(t, "01:30:12;15", tc.String())
-	assert.Equal(t, uint64(324411), tc.Frames())
-	assert.Equal(t, 5406.85, tc.Seconds())
+	assert.Equal(t, int64(324411), tc.Frames())
+	assert.Equal(t, 5412.25685, tc.Seconds())
 	assert.Equal(t, uint64(1), tc.Hour())
 	assert.Equal(t, uint64(30), tc.Minute())
 	assert.Equal(t, uint64(12), tc.Second())
@@ -82,8 +84,8 @@ func TestParse(t *testing.T) {
 	tc, err = Parse(R5994, "01:30:12:15")
 	assert.Nil(t, err)
 	assert.Equal(t, "01:30:12:15", tc.String())
-	assert.Equal(t, uint64(324735), tc.Frames())
-	assert.Equal(t, 5412.25, tc.Seconds())
+	assert.Equal(t, int64(324735), tc.Frames())
+	assert.Equal(t, 5417.66225, tc.Seconds())
 	assert.Equal(t, uint64(1), tc.Hour())
 	assert.Equal(t, uint64(30), tc.Minute())
 	assert.Equal(t, uint64(12), tc.Second())
@@ -92,8 +94,8 @@ func TestParse(t *testing.T) {
 	tc, err = Parse(R2398, "01:30:12:15")
 	assert.Nil(t, err)
 	assert.Equal(t, "01:30:12:15", tc.String())
-	assert.Equal(t, uint64(129903), tc.Frames())
-	assert.Equal(t, 5412.625, tc.Seconds())
+	assert.Equal(t, int64(129903), tc.Frames())
+	assert.Equal(t, 5418.037625, tc.Seconds())
 	assert.Equal(t, uint64(1), tc.Hour())
 	assert.Equal(t, uint64(30), tc.Minute())
 	assert.Equal(t, uint64(12), tc.Second())
@@ -102,7 +104,7 @@ func TestParse(t *testing.T) {
 	tc, err = Parse(R24, "01:30:12:15")
 	assert.Nil(t, err)
 	assert.Equal(t, "01:30:12:15", tc.String())
-	assert.Equal(t, uint64(129903), tc.Frames())
+	assert.Equal(t, int64(129903), tc.Frames())
 	assert.Equal(t, 5412.625, tc.Seconds())
 	assert.Equal(t, uint64(1), tc.Hour())
 	assert.Equal(t, uint64(30), tc.Minute())
@@ -112,7 +114,7 @@ func TestParse(t *testing.T) {
 	tc, err = Parse(R25, "01:30:12:15")
 	assert.Nil(t, err)
 	assert.Equal(t, "01:30:12:15", tc.String())
-	assert.Equal(t, uint64(135315), tc.Frames())
+	assert.Equal(t, int64(135315), tc.Frames())
 	assert.Equal(t, 5412.6, tc.Seconds())
 	assert.Equal(t, uint64(1), tc.Hour())
 	assert.Equal(t, uint64(30), tc.Minute())
@@ -122,7 +124,7 @@ func TestParse(t *testing.T) {
 	tc, err = Parse(R50, "01:30:12:15")
 	assert.Nil(t, err)
 	assert.Equal(t, "01:30:12:15", tc.String())
-	assert.Equal(t, uint64(270615), tc.Frames())
+	assert.Equal(t, int64(270615), tc.Frames())
 	assert.Equal(t, 5412.3, tc.Seconds())
 	assert.Equal(t, uint64(1), tc.Hour())
 	assert.Equal(t, uint64(30), tc.Minute())
@@ -132,7 +134,7 @@ func TestParse(t *testing.T) {
 	tc, err = Parse(R60, "01:30:12:15")
 	assert.Nil(t, err)
 	assert.Equal(t, "01:30:12:15", tc.String())
-	assert.Equal(t, uint64(324735), tc.Frames())
+	assert.Equal(t, int64(324735), tc.Frames())
 	assert.Equal(t, 5412.25, tc.Seconds())
 	assert.Equal(t, uint64(1), tc.Hour())
 	assert.Equal(t, uint64(30), tc.Minute())
@@ -142,7 +144,7 @@ func TestParse(t *testing.T) {
 	tc, err = Parse(R120, "01:30:12:15")
 	assert.Nil(t, err)
 	assert.Equal(t, "01:30:12:15", tc.String())
-	assert.Equal(t, uint64(649455), tc.Frames())
+	assert.Equal(t, int64(649455), tc.Frames())
 	assert.Equal(t, 5412.125, tc.Seconds())
 	assert.Equal(t, uint64(1), tc.Hour())
 	assert.Equal(t, uint64(30), tc.Minute())
@@ -152,7 +154,7 @@ func TestParse(t *testing.T) {
 	tc, err = Parse(R240, "01:30:12:15")
 	assert.Nil(t, err)
 	assert.Equal(t, "01:30:12:15", tc.String())
-	assert.Equal(t, uint64(1298895), tc.Frames())
+	assert.Equal(t, int64(1298895), tc.Frames())
 	assert.Equal(t, 5412.0625, tc.Seconds())
 	assert.Equal(t, uint64(1), tc.Hour())
 	assert.Equal(t, uint64(30), tc.Minute())
@@ -165,7 +167,7 @@ func TestFromFrames(t *testing.T) {
 
 	tc := FromFrames(R30, 300)
 	assert.Equal(t, "00:00:10:00", tc.String())
-	assert.Equal(t, uint64(300), tc.Frames())
+	assert.Equal(t, int64(300), tc.Frames())
 	assert.Equal(t, 10.0, tc.Seconds())
 	assert.Equal(t, uint64(0), tc.Hour())
 	assert.Equal(t, uint64(0), tc.Minute())
@@ -174,7 +176,7 @@ func TestFromFrames(t *testing.T) {
 
 	tc = FromFrames(R30, 13284300)
 	assert.Equal(t, "123:00:10:00", tc.String())
-	assert.Equal(t, uint64(13284300), tc.Frames())
+	assert.Equal(t, int64(13284300), tc.Frames())
 	assert.Equal(t, 442810.0, tc.Seconds())
 	assert.Equal(t, uint64(123), tc.Hour())
 	assert.Equal(t, uint64(0), tc.Minute())
@@ -183,7 +185,7 @@ func TestFromFrames(t *testing.T) {
 
 	tc = FromFrames(R30, 162375)
 	assert.Equal(t, "01:30:12:15", tc.String())
-	assert.Equal(t, uint64(162375), tc.Frames())
+	assert.Equal(t, int64(162375), tc.Frames())
 	assert.Equal(t, 5412.5, tc.Seconds())
 	assert.Equal(t, uint64(1), tc.Hour())
 	assert.Equal(t, uint64(30), tc.Minute())
@@ -192,8 +194,8 @@ func TestFromFrames(t *testing.T) {
 
 	tc = FromFrames(R2997DF, 162213)
 	assert.Equal(t, "01:30:12;15", tc.String())
-	assert.Equal(t, uint64(162213), tc.Frames())
-	assert.Equal(t, 5407.1, tc.Seconds())
+	assert.Equal(t, int64(162213), tc.Frames())
+	assert.Equal(t, 5412.5071, tc.Seconds())
 	assert.Equal(t, uint64(1), tc.Hour())
 	assert.Equal(t, uint64(30), tc.Minute())
 	assert.Equal(t, uint64(12), tc.Second())
@@ -201,8 +203,8 @@ func TestFromFrames(t *testing.T) {
 
 	tc = FromFrames(R2997, 162375)
 	assert.Equal(t, "01:30:12:15", tc.String())
-	assert.Equal(t, uint64(162375), tc.Frames())
-	assert.Equal(t, 5412.5, tc.Seconds())
+	assert.Equal(t, int64(162375), tc.Frames())
+	assert.Equal(t, 5417.9125, tc.Seconds())
 	assert.Equal(t, uint64(1), tc.Hour())
 	assert.Equal(t, uint64(30), tc.Minute())
 	assert.Equal(t, uint64(12), tc.Second())
@@ -210,8 +212,8 @@ func TestFromFrames(t *testing.T) {
 
 	tc = FromFrames(R5994DF, 324411)
 	assert.Equal(t, "01:30:12;15", tc.String())
-	assert.Equal(t, uint64(324411), tc.Frames())
-	assert.Equal(t, 5406.85, tc.Seconds())
+	assert.Equal(t, int64(324411), tc.Frames())
+	assert.Equal(t, 5412.25685, tc.Seconds())
 	assert.Equal(t, uint64(1), tc.Hour())
 	assert.Equal(t, uint64(30), tc.Minute())
 	assert.Equal(t, uint64(12), tc.Second())
@@ -219,8 +221,8 @@ func TestFromFrames(t *testing.T) {
 
 	tc = FromFrames(R5994, 324735)
 	assert.Equal(t, "01:30:12:15", tc.String())
-	assert.Equal(t, uint64(324735), tc.Frames())
-	assert.Equal(t, 5412.25, tc.Seconds())
+	assert.Equal(t, int64(324735), tc.Frames())
+	assert.Equal(t, 5417.66225, tc.Seconds())
 	assert.Equal(t, uint64(1), tc.Hour())
 	assert.Equal(t, uint64(30), tc.Minute())
 	assert.Equal(t, uint64(12), tc.Second())
@@ -228,8 +230,8 @@ func TestFromFrames(t *testing.T) {
 
 	tc = FromFrames(R2398, 129903)
 	assert.Equal(t, "01:30:12:15", tc.String())
-	assert.Equal(t, uint64(129903), tc.Frames())
-	assert.Equal(t, 5412.625, tc.Seconds())
+	assert.Equal(t, int64(129903), tc.Frames())
+	assert.Equal(t, 5418.037625, tc.Seconds())
 	assert.Equal(t, uint64(1), tc.Hour())
 	assert.Equal(t, uint64(30), tc.Minute())
 	assert.Equal(t, uint64(12), tc.Second())
@@ -237,7 +239,7 @@ func TestFromFrames(t *testing.T) {
 
 	tc = FromFrames(R24, 129903)
 	assert.Equal(t, "01:30:12:15", tc.String())
-	assert.Equal(t, uint64(129903), tc.Frames())
+	assert.Equal(t, int64(129903), tc.Frames())
 	assert.Equal(t, 5412.625, tc.Seconds())
 	assert.Equal(t, uint64(1), tc.Hour())
 	assert.Equal(t, uint64(30), tc.Minute())
@@ -246,7 +248,7 @@ func TestFromFrames(t *testing.T) {
 
 	tc = FromFrames(R25, 135315)
 	assert.Equal(t, "01:30:12:15", tc.String())
-	assert.Equal(t, uint64(135315), tc.Frames())
+	assert.Equal(t, int64(135315), tc.Frames())
 	assert.Equal(t, 5412.6, tc.Seconds())
 	assert.Equal(t, uint64(1), tc.Hour())
 	assert.Equal(t, uint64(30), tc.Minute())
@@ -255,7 +257,7 @@ func TestFromFrames(t *testing.T) {
 
 	tc = FromFrames(R50, 270615)
 	assert.Equal(t, "01:30:12:15", tc.String())
-	assert.Equal(t, uint64(270615), tc.Frames())
+	assert.Equal(t, int64(270615), tc.Frames())
 	assert.Equal(t, 5412.3, tc.Seconds())
 	assert.Equal(t, uint64(1), tc.Hour())
 	assert.Equal(t, uint64(30), tc.Minute())
@@ -264,7 +266,7 @@ func TestFromFrames(t *testing.T) {
 
 	tc = FromFrames(R60, 324735)
 	assert.Equal(t, "01:30:12:15", tc.String())
-	assert.Equal(t, uint64(324735), tc.Frames())
+	assert.Equal(t, int64(324735), tc.Frames())
 	assert.Equal(t, 5412.25, tc.Seconds())
 	assert.Equal(t, uint64(1), tc.Hour())
 	assert.Equal(t, uint64(30), tc.Minute())
@@ -273,7 +275,7 @@ func TestFromFrames(t *testing.T) {
 
 	tc = FromFrames(R120, 649455)
 	assert.Equal(t, "01:30:12:15", tc.String())
-	assert.Equal(t, uint64(649455), tc.Frames())
+	assert.Equal(t, int64(649455), tc.Frames())
 	assert.Equal(t, 5412.125, tc.Seconds())
 	assert.Equal(t, uint64(1), tc.Hour())
 	assert.Equal(t, uint64(30), tc.Minute())
@@ -282,7 +284,7 @@ func TestFromFrames(t *testing.T) {
 
 	tc = FromFrames(R240, 1298895)
 	assert.Equal(t, "01:30:12:15", tc.String())
-	assert.Equal(t, uint64(1298895), tc.Frames())
+	assert.Equal(t, int64(1298895), tc.Frames())
 	assert.Equal(t, 5412.0625, tc.Seconds())
 	assert.Equal(t, uint64(1), tc.Hour())
 	assert.Equal(t, uint64(30), tc.Minute())
@@ -296,7 +298,7 @@ func TestFromSeconds(t *testing.T) {
 	tc, err := FromSeconds(R30, 10.0)
 	assert.Nil(t, err)
 	assert.Equal(t, "00:00:10:00", tc.String())
-	assert.Equal(t, uint64(300), tc.Frames())
+	assert.Equal(t, int64(300), tc.Frames())
 	assert.Equal(t, 10.0, tc.Seconds())
 	assert.Equal(t, uint64(0), tc.Hour())
 	assert.Equal(t, uint64(0), tc.Minute())
@@ -306,7 +308,7 @@ func TestFromSeconds(t *testing.T) {
 	tc, err = FromSeconds(R30, 442810.0)
 	assert.Nil(t, err)
 	assert.Equal(t, "123:00:10:00", tc.String())
-	assert.Equal(t, uint64(13284300), tc.Frames())
+	assert.Equal(t, int64(13284300), tc.Frames())
 	assert.Equal(t, 442810.0, tc.Seconds())
 	assert.Equal(t, uint64(123), tc.Hour())
 	assert.Equal(t, uint64(0), tc.Minute())
@@ -316,58 +318,58 @@ func TestFromSeconds(t *testing.T) {
 	tc, err = FromSeconds(R30, 5412.5)
 	assert.Nil(t, err)
 	assert.Equal(t, "01:30:12:15", tc.String())
-	assert.Equal(t, uint64(162375), tc.Frames())
+	assert.Equal(t, int64(162375), tc.Frames())
 	assert.Equal(t, 5412.5, tc.Seconds())
 	assert.Equal(t, uint64(1), tc.Hour())
 	assert.Equal(t, uint64(30), tc.Minute())
 	assert.Equal(t, uint64(12), tc.Second())
 	assert.Equal(t, uint64(15), tc.Frame())
 
-	tc, err = FromSeconds(R2997DF, 5407.1)
+	tc, err = FromSeconds(R2997DF, 5412.5071)
 	assert.Nil(t, err)
 	assert.Equal(t, "01:30:12;15", tc.String())
-	assert.Equal(t, uint64(162213), tc.Frames())
-	assert.Equal(t, 5407.1, tc.Seconds())
+	assert.Equal(t, int64(162213), tc.Frames())
+	assert.Equal(t, 5412.5071, tc.Seconds())
 	assert.Equal(t, uint64(1), tc.Hour())
 	assert.Equal(t, uint64(30), tc.Minute())
 	assert.Equal(t, uint64(12), tc.Second())
 	assert.Equal(t, uint64(15), tc.Frame())
 
-	tc, err = FromSeconds(R2997, 5412.5)
+	tc, err = FromSeconds(R2997, 5417.9125)
 	assert.Nil(t, err)
 	assert.Equal(t, "01:30:12:15", tc.String())
-	assert.Equal(t, uint64(162375), tc.Frames())
-	assert.Equal(t, 5412.5, tc.Seconds())
+	assert.Equal(t, int64(162375), tc.Frames())
+	assert.Equal(t, 5417.9125, tc.Seconds())
 	assert.Equal(t, uint64(1), tc.Hour())
 	assert.Equal(t, uint64(30), tc.Minute())
 	assert.Equal(t, uint64(12), tc.Second())
 	assert.Equal(t, uint64(15), tc.Frame())
 
-	tc, err = FromSeconds(R5994DF, 5406.85)
+	tc, err = FromSeconds(R5994DF, 5412.25685)
 	assert.Nil(t, err)
 	assert.Equal(t, "01:30:12;15", tc.String())
-	assert.Equal(t, uint64(324411), tc.Frames())
-	assert.Equal(t, 5406.85, tc.Seconds())
+	assert.Equal(t, int64(324411), tc.Frames())
+	assert.Equal(t, 5412.25685, tc.Seconds())
 	assert.Equal(t, uint64(1), tc.Hour())
 	assert.Equal(t, uint64(30), tc.Minute())
 	assert.Equal(t, uint64(12), tc.Second())
 	assert.Equal(t, uint64(15), tc.Frame())
 
-	tc, err = FromSeconds(R5994, 5412.25)
+	tc, err = FromSeconds(R5994, 5417.66225)
 	assert.Nil(t, err)
 	assert.Equal(t, "01:30:12:15", tc.String())
-	assert.Equal(t, uint64(324735), tc.Frames())
-	assert.Equal(t, 5412.25, tc.Seconds())
+	assert.Equal(t, int64(324735), tc.Frames())
+	assert.Equal(t, 5417.66225, tc.Seconds())
 	assert.Equal(t, uint64(1), tc.Hour())
 	assert.Equal(t, uint64(30), tc.Minute())
 	assert.Equal(t, uint64(12), tc.Second())
 	assert.Equal(t, uint64(15), tc.Frame())
 
-	tc, err = FromSeconds(R2398, 5412.625)
+	tc, err = FromSeconds(R2398, 5418.037625)
 	assert.Nil(t, err)
 	assert.Equal(t, "01:30:12:15", tc.String())
-	assert.Equal(t, uint64(129903), tc.Frames())
-	assert.Equal(t, 5412.625, tc.Seconds())
+	assert.Equal(t, int64(129903), tc.Frames())
+	assert.Equal(t, 5418.037625, tc.Seconds())
 	assert.Equal(t, uint64(1), tc.Hour())
 	assert.Equal(t, uint64(30), tc.Minute())
 	assert.Equal(t, uint64(12), tc.Second())
@@ -376,7 +378,7 @@ func TestFromSeconds(t *testing.T) {
 	tc, err = FromSeconds(R24, 5412.625)
 	assert.Nil(t, err)
 	assert.Equal(t, "01:30:12:15", tc.String())
-	assert.Equal(t, uint64(129903), tc.Frames())
+	assert.Equal(t, int64(129903), tc.Frames())
 	assert.Equal(t, 5412.625, tc.Seconds())
 	assert.Equal(t, uint64(1), tc.Hour())
 	assert.Equal(t, uint64(30), tc.Minute())
@@ -386,7 +388,7 @@ func TestFromSeconds(t *testing.T) {
 	tc, err = FromSeconds(R25, 5412.6)
 	assert.Nil(t, err)
 	assert.Equal(t, "01:30:12:15", tc.String())
-	assert.Equal(t, uint64(135315), tc.Frames())
+	assert.Equal(t, int64(135315), tc.Frames())
 	assert.Equal(t, 5412.6, tc.Seconds())
 	assert.Equal(t, uint64(1), tc.Hour())
 	assert.Equal(t, uint64(30), tc.Minute())
@@ -396,7 +398,7 @@ func TestFromSeconds(t *testing.T) {
 	tc, err = FromSeconds(R50, 5412.3)
 	assert.Nil(t, err)
 	assert.Equal(t, "01:30:12:15", tc.String())
-	assert.Equal(t, uint64(270615), tc.Frames())
+	assert.Equal(t, int64(270615), tc.Frames())
 	assert.Equal(t, 5412.3, tc.Seconds())
 	assert.Equal(t, uint64(1), tc.Hour())
 	assert.Equal(t, uint64(30), tc.Minute())
@@ -406,7 +408,7 @@ func TestFromSeconds(t *testing.T) {
 	tc, err = FromSeconds(R60, 5412.25)
 	assert.Nil(t, err)
 	assert.Equal(t, "01:30:12:15", tc.String())
-	assert.Equal(t, uint64(324735), tc.Frames())
+	assert.Equal(t, int64(324735), tc.Frames())
 	assert.Equal(t, 5412.25, tc.Seconds())
 	assert.Equal(t, uint64(1), tc.Hour())
 	assert.Equal(t, uint64(30), tc.Minute())
@@ -416,7 +418,7 @@ func TestFromSeconds(t *testing.T) {
 	tc, err = FromSeconds(R120, 5412.125)
 	assert.Nil(t, err)
 	assert.Equal(t, "01:30:12:15", tc.String())
-	assert.Equal(t, uint64(649455), tc.Frames())
+	assert.Equal(t, int64(649455), tc.Frames())
 	assert.Equal(t, 5412.125, tc.Seconds())
 	assert.Equal(t, uint64(1), tc.Hour())
 	assert.Equal(t, uint64(30), tc.Minute())
@@ -426,7 +428,7 @@ func TestFromSeconds(t *testing.T) {
 	tc, err = FromSeconds(R240, 5412.0625)
 	assert.Nil(t, err)
 	assert.Equal(t, "01:30:12:15", tc.String())
-	assert.Equal(t, uint64(1298895), tc.Frames())
+	assert.Equal(t, int64(1298895), tc.Frames())
 	assert.Equal(t, 5412.0625, tc.Seconds())
 	assert.Equal(t, uint64(1), tc.Hour())
 	assert.Equal(t, uint64(30), tc.Minute())
@@ -450,15 +452,15 @@ func TestInvalidTimecodes(t *testing.T) {
 	_, err = Parse(R30, "not a timecode")
 	assert.NotNil(t, err)
 
-	_, err = Parse(R30, "-10:00:00:00")
-	assert.NotNil(t, err)
-
 	_, err = Parse(R30, "00:00:00:0.123")
 	assert.NotNil(t, err)
 }
 
 func TestInvalidSeconds(t *testing.T) {
-	_, err := FromSeconds(R30, -1.0)
+	_, err := FromSeconds(R30, math.NaN())
+	assert.NotNil(t, err)
+
+	_, err = FromSeconds(R30, math.Inf(1))
 	assert.NotNil(t, err)
 }
 
@@ -493,25 +495,33 @@ func TestAddTimecodes(t *testing.T) {
 	assert.Nil(t, err)
 	right, err := Parse(R30, "00:00:50:00")
 	assert.Nil(t, err)
-	assert.Equal(t, "00:01:00:00", left.Add(right.Frames()).String())
+	result, err := left.Add(right.Frames())
+	assert.Nil(t, err)
+	assert.Equal(t, "00:01:00:00", result.String())
 
 	left, err = Parse(R30, "00:00:10:00")
 	assert.Nil(t, err)
 	right, err = Parse(R30, "00:00:55:00")
 	assert.Nil(t, err)
-	assert.Equal(t, "00:01:05:00", left.Add(right.Frames()).String())
+	result, err = left.Add(right.Frames())
+	assert.Nil(t, err)
+	assert.Equal(t, "00:01:05:00", result.String())
 
 	left, err = Parse(R30, "59:59:59:29")
 	assert.Nil(t, err)
 	right, err = Parse(R30, "00:00:00:01")
 	assert.Nil(t, err)
-	assert.Equal(t, "60:00:00:00", left.Add(right.Frames()).String())
+	result, err = left.Add(right.Frames())
+	assert.Nil(t, err)
+	assert.Equal(t, "60:00:00:00", result.String())
 
 	left, err = Parse(R30, "59:59:59:29")
 	assert.Nil(t, err)
 	right, err = Parse(R30, "00:10:00:01")
 	assert.Nil(t, err)
-	assert.Equal(t, "60:10:00:00", left.Add(right.Frames()).String())
+	result, err = left.Add(right.Frames())
+	assert.Nil(t, err)
+	assert.Equal(t, "60:10:00:00", result.String())
 
 }
 
@@ -528,8 +538,10 @@ func TestSubTimecodes(t *testing.T) {
 	assert.Nil(t, err)
 	right, err = Parse(R30, "00:00:00:20")
 	assert.Nil(t, err)
-	_, err = left.Sub(right.Frames())
-	assert.NotNil(t, err)
+	result, err = left.Sub(right.Frames())
+	assert.Nil(t, err)
+	assert.Equal(t, "-00:00:00:10", result.String())
+	assert.Equal(t, int64(-10), result.Frames())
 }
 
 func TestRate(t *testing.T) {
@@ -538,3 +550,277 @@ func TestRate(t *testing.T) {
 	rate := tc.Rate()
 	assert.Equal(t, 30.0, rate.FPS())
 }
+
+func TestExactSeconds(t *testing.T) {
+	// 24 hours of labels at 23.976 is exactly 86486.4 seconds of real time
+	tc, err := Parse(R2398, "24:00:00:00")
+	assert.Nil(t, err)
+	assert.Equal(t, 86486.4, tc.Seconds())
+
+	tc, err = FromSeconds(R2398, 86486.4)
+	assert.Nil(t, err)
+	assert.Equal(t, "24:00:00:00", tc.String())
+
+	// every frame must survive a round trip through seconds
+	for _, rate := range []Rate{R2997, R2997DF, R5994, R5994DF, R2398} {
+		for frames := int64(-100000); frames < 100000; frames += 997 {
+			tc, err := FromSeconds(rate, FromFrames(rate, frames).Seconds())
+			assert.Nil(t, err)
+			assert.Equal(t, frames, tc.Frames())
+		}
+	}
+}
+
+func TestNegativeTimecodes(t *testing.T) {
+	tc, err := Parse(R30, "-00:00:01:12")
+	assert.Nil(t, err)
+	assert.Equal(t, "-00:00:01:12", tc.String())
+	assert.Equal(t, int64(-42), tc.Frames())
+	assert.Equal(t, -1.4, tc.Seconds())
+	assert.True(t, tc.Negative())
+	assert.Equal(t, uint64(0), tc.Hour())
+	assert.Equal(t, uint64(0), tc.Minute())
+	assert.Equal(t, uint64(1), tc.Second())
+	assert.Equal(t, uint64(12), tc.Frame())
+
+	tc, err = Parse(R30, "-10:00:00:00")
+	assert.Nil(t, err)
+	assert.Equal(t, "-10:00:00:00", tc.String())
+	assert.Equal(t, int64(-1080000), tc.Frames())
+
+	tc, err = Parse(R2997DF, "-01:30:12;15")
+	assert.Nil(t, err)
+	assert.Equal(t, "-01:30:12;15", tc.String())
+	assert.Equal(t, int64(-162213), tc.Frames())
+
+	tc, err = Parse(R30, "-00:00:00:00")
+	assert.Nil(t, err)
+	assert.Equal(t, "00:00:00:00", tc.String())
+	assert.False(t, tc.Negative())
+
+	tc = FromFrames(R25, -26)
+	assert.Equal(t, "-00:00:01:01", tc.String())
+
+	tc, err = FromSeconds(R30, -1.4)
+	assert.Nil(t, err)
+	assert.Equal(t, "-00:00:01:12", tc.String())
+
+	// truncated toward zero
+	tc, err = FromSeconds(R30, -0.01)
+	assert.Nil(t, err)
+	assert.Equal(t, "00:00:00:00", tc.String())
+
+	_, err = Parse(R30, "99999999999999:00:00:00")
+	assert.NotNil(t, err)
+}
+
+func TestSignedArithmetic(t *testing.T) {
+	tc, err := Parse(R25, "00:00:01:00")
+	assert.Nil(t, err)
+
+	for _, test := range []struct {
+		op       func(int64) (Timecode, error)
+		frames   int64
+		expected string
+	}{
+		{tc.Add, -10, "00:00:00:15"},
+		{tc.Add, -30, "-00:00:00:05"},
+		{tc.Sub, -10, "00:00:01:10"},
+		{tc.Sub, 50, "-00:00:01:00"},
+	} {
+		result, err := test.op(test.frames)
+		assert.Nil(t, err)
+		assert.Equal(t, test.expected, result.String())
+	}
+
+	offset, err := Parse(R25, "-00:00:00:12")
+	assert.Nil(t, err)
+	result, err := tc.Add(offset.Frames())
+	assert.Nil(t, err)
+	assert.Equal(t, "00:00:00:13", result.String())
+	result, err = tc.Sub(offset.Frames())
+	assert.Nil(t, err)
+	assert.Equal(t, "00:00:01:12", result.String())
+}
+
+func TestArithmeticBounds(t *testing.T) {
+	tests := []struct {
+		name     string
+		frames   int64
+		add      int64
+		sub      int64
+		expected int64
+		err      error
+	}{
+		{name: "add to max", frames: math.MaxInt64 - 1, add: 1, expected: math.MaxInt64},
+		{name: "add past max", frames: math.MaxInt64, add: 1, err: ErrOverflow},
+		{name: "add max to max", frames: math.MaxInt64, add: math.MaxInt64, err: ErrOverflow},
+		{name: "add to min", frames: math.MinInt64 + 1, add: -1, expected: math.MinInt64},
+		{name: "add past min", frames: math.MinInt64, add: -1, err: ErrUnderflow},
+		{name: "add min to min", frames: math.MinInt64, add: math.MinInt64, err: ErrUnderflow},
+		{name: "add min to max", frames: math.MaxInt64, add: math.MinInt64, expected: -1},
+		{name: "sub below zero", frames: 10, sub: 20, expected: -10},
+		{name: "sub to min", frames: math.MinInt64 + 1, sub: 1, expected: math.MinInt64},
+		{name: "sub past min", frames: math.MinInt64, sub: 1, err: ErrUnderflow},
+		{name: "sub max from min", frames: -2, sub: math.MaxInt64, err: ErrUnderflow},
+		{name: "sub to max", frames: math.MaxInt64 - 1, sub: -1, expected: math.MaxInt64},
+		{name: "sub past max", frames: math.MaxInt64, sub: -1, err: ErrOverflow},
+		{name: "sub min from zero", frames: 0, sub: math.MinInt64, err: ErrOverflow},
+		{name: "sub min from min", frames: math.MinInt64, sub: math.MinInt64, expected: 0},
+	}
+
+	for _, test := range tests {
+		tc := FromFrames(R30, test.frames)
+
+		var result Timecode
+		var err error
+		if test.sub != 0 {
+			result, err = tc.Sub(test.sub)
+		} else {
+			result, err = tc.Add(test.add)
+		}
+
+		if test.err != nil {
+			assert.True(t, errors.Is(err, test.err), test.name)
+			continue
+		}
+		assert.Nil(t, err, test.name)
+		assert.Equal(t, test.expected, result.Frames(), test.name)
+	}
+
+	_, err := Diff(FromFrames(R30, math.MaxInt64), FromFrames(R30, -1))
+	assert.True(t, errors.Is(err, ErrOverflow))
+
+	_, err = Diff(FromFrames(R30, math.MinInt64), FromFrames(R30, 1))
+	assert.True(t, errors.Is(err, ErrUnderflow))
+
+	_, err = Parse(R30, "99999999999999:00:00:00")
+	assert.True(t, errors.Is(err, ErrOverflow))
+
+	_, err = FromSeconds(R30, 1e300)
+	assert.True(t, errors.Is(err, ErrOverflow))
+
+	_, err = FromSeconds(R30, -1e300)
+	assert.True(t, errors.Is(err, ErrUnderflow))
+
+	// labels of the most extreme timecodes don't wrap around
+	assert.Equal(t, "85401592933840:31:00:07", FromFrames(R30, math.MaxInt64).String())
+	assert.Equal(t, "-85401592933840:31:00:08", FromFrames(R30, math.MinInt64).String())
+}
+
+func TestLabelSeconds(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		rate         Rate
+		timecode     string
+		labelSeconds float64
+		seconds      float64
+	}{
+		{R30, "01:30:12:15", 5412.5, 5412.5},
+		{R2997, "01:30:12:15", 5412.5, 5417.9125},
+		{R2997DF, "01:30:12;15", 5412.5, 5412.5071},
+		{R2997DF, "00:10:00;00", 600, 599.9994},
+		{R5994DF, "01:30:12;15", 5412.25, 5412.25685},
+		{R2398, "01:30:12:15", 5412.625, 5418.037625},
+		{R2398, "-01:30:12:15", -5412.625, -5418.037625},
+	}
+
+	for _, test := range tests {
+		tc, err := Parse(test.rate, test.timecode)
+		assert.Nil(t, err)
+		assert.Equal(t, test.labelSeconds, tc.LabelSeconds(), test.timecode)
+		assert.Equal(t, test.seconds, tc.Seconds(), test.timecode)
+
+		fromLabel, err := FromLabelSeconds(test.rate, test.labelSeconds)
+		assert.Nil(t, err)
+		assert.Equal(t, tc, fromLabel, test.timecode)
+
+		fromReal, err := FromSeconds(test.rate, test.seconds)
+		assert.Nil(t, err)
+		assert.Equal(t, tc, fromReal, test.timecode)
+	}
+
+	// a minute of drop frame labels is two frames short of a real minute
+	tc, err := FromLabelSeconds(R2997DF, 60)
+	assert.Nil(t, err)
+	assert.Equal(t, "00:01:00;02", tc.String())
+	assert.Equal(t, 60+2.0/30, tc.LabelSeconds())
+
+	// while a real minute hasn't quite reached the label 00:01:00;02
+	tc, err = FromSeconds(R2997DF, 60)
+	assert.Nil(t, err)
+	assert.Equal(t, "00:00:59;28", tc.String())
+
+	for _, rate := range []Rate{R2997, R2997DF, R5994DF, R2398, R24} {
+		for frames := int64(-50000); frames < 50000; frames += 331 {
+			tc, err := FromLabelSeconds(rate, FromFrames(rate, frames).LabelSeconds())
+			assert.Nil(t, err)
+			assert.Equal(t, frames, tc.Frames())
+		}
+	}
+
+	_, err = FromLabelSeconds(R30, math.NaN())
+	assert.NotNil(t, err)
+
+	_, err = FromLabelSeconds(R30, 1e300)
+	assert.True(t, errors.Is(err, ErrOverflow))
+
+	_, err = FromLabelSeconds(R30, -1e300)
+	assert.True(t, errors.Is(err, ErrUnderflow))
+}
+
+func TestDroppedFrameLabels(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		rate    Rate
+		dropped []string
+		valid   []string
+	}{
+		{
+			rate:    R2997DF,
+			dropped: []string{"00:01:00;00", "00:01:00;01", "00:09:00;01", "01:11:00;00", "-00:01:00;00"},
+			valid:   []string{"00:00:00;00", "00:01:00;02", "00:10:00;00", "00:10:00;01", "01:00:00;00", "00:01:01;00"},
+		},
+		{
+			rate:    R5994DF,
+			dropped: []string{"00:01:00;00", "00:01:00;01", "00:01:00;02", "00:01:00;03", "00:59:00;03"},
+			valid:   []string{"00:01:00;04", "00:20:00;00", "00:20:00;03", "00:01:01;00"},
+		},
+		{
+			rate:  R2997,
+			valid: []string{"00:01:00:00", "00:01:00:01"},
+		},
+	}
+
+	for _, test := range tests {
+		for _, s := range test.dropped {
+			_, err := Parse(test.rate, s)
+			assert.True(t, errors.Is(err, ErrDroppedFrame), s)
+		}
+
+		for _, s := range test.valid {
+			tc, err := Parse(test.rate, s)
+			assert.Nil(t, err, s)
+			assert.Equal(t, s, tc.String(), s)
+		}
+	}
+
+	tc, err := Parse(R2997DF, "00:01:00;00", SnapDroppedFrames())
+	assert.Nil(t, err)
+	assert.Equal(t, "00:01:00;02", tc.String())
+	assert.Equal(t, int64(1800), tc.Frames())
+
+	tc, err = Parse(R2997DF, "00:01:00;01", SnapDroppedFrames())
+	assert.Nil(t, err)
+	assert.Equal(t, "00:01:00;02", tc.String())
+
+	tc, err = Parse(R5994DF, "-00:01:00;03", SnapDroppedFrames())
+	assert.Nil(t, err)
+	assert.Equal(t, "-00:01:00;04", tc.String())
+
+	tc, err = Parse(R2997DF, "00:10:00;00", SnapDroppedFrames())
+	assert.Nil(t, err)
+	assert.Equal(t, "00:10:00;00", tc.String())
+}
@@ -0,0 +1,68 @@
+package timecode
+
+import "fmt"
+
+// Wrap describes how a Timecode behaves when it passes 24:00:00:00 or goes below 00:00:00:00.
+type Wrap int
+
+const (
+	// WrapNone allows hours to grow without bound and allows negative timecodes. This is the default.
+	WrapNone Wrap = iota
+
+	// Wrap24Hour rolls over at midnight the same as time of day and LTC timecode. Every Timecode is
+	// kept between 00:00:00:00 and 23:59:59:{fps-1} so 23:59:59:29 plus one frame is 00:00:00:00 and
+	// 00:00:00:00 minus one frame is 23:59:59:29.
+	Wrap24Hour
+
+	// WrapError returns an ErrOverflow or ErrUnderflow error from any operation that would result in a
+	// Timecode at or past 24:00:00:00 or below 00:00:00:00. Functions without an error result, such as
+	// FromFrames, don't check the range and leave the frames unchanged.
+	WrapError
+)
+
+// WithWrap returns a copy of the Rate using the passed Wrap mode. Timecodes with the same frame rate
+// but different Wrap modes use different Rates and can't be compared.
+func (r Rate) WithWrap(wrap Wrap) Rate {
+	r.wrap = wrap
+	return r
+}
+
+// Wrap returns the Wrap mode of the Rate.
+func (r Rate) Wrap() Wrap {
+	return r.wrap
+}
+
+// FramesPerDay returns the number of frames in 24 hours of timecode labels. For example 2592000 for 30
+// fps and 2589408 for 29.97 DF.
+func (r Rate) FramesPerDay() int64 {
+	frames, _ := labelToFrames(r, 24, 0, 0, 0)
+	return frames
+}
+
+// bound applies the Wrap mode of the Rate to frames.
+func (r Rate) bound(frames int64) (int64, error) {
+	switch r.wrap {
+	case Wrap24Hour:
+		return r.wrapDay(frames), nil
+	case WrapError:
+		if frames < 0 {
+			return 0, fmt.Errorf("%w: %d frames is before 00:00:00:00", ErrUnderflow, frames)
+		}
+		if frames >= r.FramesPerDay() {
+			return 0, fmt.Errorf("%w: %d frames is not before 24:00:00:00", ErrOverflow, frames)
+		}
+	}
+
+	return frames, nil
+}
+
+// wrapDay returns frames wrapped to a value between 0 and FramesPerDay.
+func (r Rate) wrapDay(frames int64) int64 {
+	day := r.FramesPerDay()
+	frames %= day
+	if frames < 0 {
+		frames += day
+	}
+
+	return frames
+}
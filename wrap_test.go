@@ -0,0 +1,146 @@
+package timecode
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFramesPerDay(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, int64(2592000), R30.FramesPerDay())
+	assert.Equal(t, int64(2592000), R2997.FramesPerDay())
+	assert.Equal(t, int64(2589408), R2997DF.FramesPerDay())
+	assert.Equal(t, int64(5178816), R5994DF.FramesPerDay())
+	assert.Equal(t, int64(2160000), R25.FramesPerDay())
+}
+
+func TestWrap24Hour(t *testing.T) {
+	t.Parallel()
+
+	rate := R2997DF.WithWrap(Wrap24Hour)
+	assert.Equal(t, Wrap24Hour, rate.Wrap())
+	assert.Equal(t, WrapNone, R2997DF.Wrap())
+	assert.NotEqual(t, R2997DF, rate)
+
+	tc, err := Parse(rate, "23:59:59;29")
+	assert.Nil(t, err)
+
+	next, err := tc.Add(1)
+	assert.Nil(t, err)
+	assert.Equal(t, "00:00:00;00", next.String())
+
+	next, err = tc.Add(rate.FramesPerDay()*3 + 31)
+	assert.Nil(t, err)
+	assert.Equal(t, "00:00:01;00", next.String())
+
+	prev, err := next.Sub(32)
+	assert.Nil(t, err)
+	assert.Equal(t, "23:59:59;28", prev.String())
+
+	prev, err = FromFrames(rate, 0).Add(-1)
+	assert.Nil(t, err)
+	assert.Equal(t, "23:59:59;29", prev.String())
+
+	tc, err = Parse(rate, "25:00:00;00")
+	assert.Nil(t, err)
+	assert.Equal(t, "01:00:00;00", tc.String())
+
+	tc, err = Parse(rate, "-00:00:01;00")
+	assert.Nil(t, err)
+	assert.Equal(t, "23:59:59;00", tc.String())
+
+	assert.Equal(t, "23:59:59;29", FromFrames(rate, -1).String())
+	assert.Equal(t, "00:00:00;00", FromFrames(rate, rate.FramesPerDay()).String())
+
+	tc, err = FromSeconds(R25.WithWrap(Wrap24Hour), 86401)
+	assert.Nil(t, err)
+	assert.Equal(t, "00:00:01:00", tc.String())
+
+	tc, err = FromLabelSeconds(R25.WithWrap(Wrap24Hour), -1)
+	assert.Nil(t, err)
+	assert.Equal(t, "23:59:59:00", tc.String())
+}
+
+func TestWrap24HourDiff(t *testing.T) {
+	t.Parallel()
+
+	rate := R25.WithWrap(Wrap24Hour)
+
+	tests := []struct {
+		a, b     string
+		expected int64
+	}{
+		{"00:00:10:00", "23:59:50:00", 500},
+		{"23:59:50:00", "00:00:10:00", -500},
+		{"01:00:00:00", "00:00:00:00", 90000},
+		{"12:00:00:00", "00:00:00:00", 1080000},
+		{"12:00:00:01", "00:00:00:00", -1079999},
+		{"00:00:00:00", "00:00:00:00", 0},
+	}
+
+	for _, test := range tests {
+		a, err := Parse(rate, test.a)
+		assert.Nil(t, err)
+		b, err := Parse(rate, test.b)
+		assert.Nil(t, err)
+
+		diff, err := Diff(a, b)
+		assert.Nil(t, err)
+		assert.Equal(t, test.expected, diff, test.a+" - "+test.b)
+	}
+
+	_, err := Diff(FromFrames(rate, 0), FromFrames(R25, 0))
+	assert.True(t, errors.Is(err, ErrRateMismatch))
+}
+
+func TestWrapError(t *testing.T) {
+	t.Parallel()
+
+	rate := R30.WithWrap(WrapError)
+
+	tc, err := Parse(rate, "23:59:59:29")
+	assert.Nil(t, err)
+
+	_, err = tc.Add(1)
+	assert.True(t, errors.Is(err, ErrOverflow))
+
+	_, err = tc.Sub(-1)
+	assert.True(t, errors.Is(err, ErrOverflow))
+
+	zero, err := tc.Sub(tc.Frames())
+	assert.Nil(t, err)
+	assert.Equal(t, "00:00:00:00", zero.String())
+
+	_, err = zero.Sub(1)
+	assert.True(t, errors.Is(err, ErrUnderflow))
+
+	_, err = Parse(rate, "24:00:00:00")
+	assert.True(t, errors.Is(err, ErrOverflow))
+
+	_, err = Parse(rate, "-00:00:00:01")
+	assert.True(t, errors.Is(err, ErrUnderflow))
+
+	_, err = FromSeconds(rate, 86400)
+	assert.True(t, errors.Is(err, ErrOverflow))
+
+	_, err = FromLabelSeconds(rate, -0.5)
+	assert.True(t, errors.Is(err, ErrUnderflow))
+
+	diff, err := Diff(tc, zero)
+	assert.Nil(t, err)
+	assert.Equal(t, rate.FramesPerDay()-1, diff)
+}
+
+func TestWrapNone(t *testing.T) {
+	t.Parallel()
+
+	tc, err := Parse(R30, "23:59:59:29")
+	assert.Nil(t, err)
+
+	next, err := tc.Add(1)
+	assert.Nil(t, err)
+	assert.Equal(t, "24:00:00:00", next.String())
+}